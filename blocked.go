@@ -0,0 +1,162 @@
+package sluggable
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//go:embed blockedwords.txt
+var defaultBlockedWords string
+
+// BlockedWordAction decides what happens to slugs containing a blocked word.
+type BlockedWordAction int
+
+const (
+	BlockReject BlockedWordAction = iota // Fail with ErrBlockedSlug
+	BlockMask                            // Drop the blocked words from the slug
+)
+
+// DefaultBlockedWords returns the bundled list of English profanity and slurs, to pass to WithBlockedWords.
+func DefaultBlockedWords() []string {
+	var words []string
+
+	for _, line := range strings.Split(defaultBlockedWords, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+
+	return words
+}
+
+// WithBlockedWords rejects slugs containing one of the words with ErrBlockedSlug, so user-provided titles
+// can't produce embarrassing URLs. Whole words are matched case-insensitively after slugifying, so "Scunthorpe"
+// isn't blocked by a word it contains. Repeated calls add words.
+func WithBlockedWords(words ...string) sluggableOption {
+	return func(opts *options) {
+		blocked := opts.blockedWords[:len(opts.blockedWords):len(opts.blockedWords)]
+
+		for _, word := range words {
+			if term := slugWords(strings.ToLower(word)); len(term) > 0 {
+				blocked = append(blocked, term)
+			}
+		}
+
+		opts.blockedWords = blocked
+	}
+}
+
+// WithBlockedWordAction sets whether slugs with blocked words are rejected (the default) or masked.
+// Masked slugs that end up empty fall back to WithUntitled and WithEmptyFallback.
+func WithBlockedWordAction(action BlockedWordAction) sluggableOption {
+	return func(opts *options) {
+		opts.blockedWordAction = action
+	}
+}
+
+// wordSpan is the position of an alphanumeric run in a slug.
+type wordSpan struct {
+	start, end int
+}
+
+func wordSpans(slug string) []wordSpan {
+	var spans []wordSpan
+
+	start := -1
+
+	for i, r := range slug {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			spans = append(spans, wordSpan{start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		spans = append(spans, wordSpan{start: start, end: len(slug)})
+	}
+
+	return spans
+}
+
+func slugWords(slug string) []string {
+	spans := wordSpans(slug)
+	words := make([]string, len(spans))
+
+	for i, span := range spans {
+		words[i] = slug[span.start:span.end]
+	}
+
+	return words
+}
+
+// blockWords rejects or masks the blocked words of the slug.
+func (o options) blockWords(slug string) (string, error) {
+	if len(o.blockedWords) == 0 || slug == "" {
+		return slug, nil
+	}
+
+	spans := wordSpans(slug)
+	words := slugWords(strings.ToLower(slug))
+	blocked := make([]bool, len(words))
+	found := false
+
+	for i := range words {
+		for _, term := range o.blockedWords {
+			if !hasWordsAt(words, term, i) {
+				continue
+			}
+
+			if o.blockedWordAction == BlockReject {
+				return "", fmt.Errorf("%w: %q contains %q", ErrBlockedSlug, slug, strings.Join(term, " "))
+			}
+
+			for j := range term {
+				blocked[i+j] = true
+			}
+
+			found = true
+		}
+	}
+
+	if !found {
+		return slug, nil
+	}
+
+	// Keep the separator in front of every remaining word, except the first
+	var masked strings.Builder
+
+	for i, span := range spans {
+		if blocked[i] {
+			continue
+		}
+
+		if masked.Len() > 0 && i > 0 {
+			masked.WriteString(slug[spans[i-1].end:span.start])
+		}
+
+		masked.WriteString(slug[span.start:span.end])
+	}
+
+	return masked.String(), nil
+}
+
+func hasWordsAt(words, term []string, at int) bool {
+	if at+len(term) > len(words) {
+		return false
+	}
+
+	for j, word := range term {
+		if words[at+j] != word {
+			return false
+		}
+	}
+
+	return true
+}
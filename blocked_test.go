@@ -0,0 +1,55 @@
+package sluggable
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithBlockedWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    string
+		wantErr error
+	}{
+		{name: "allowed", options: []sluggableOption{WithBlockedWords("darn")}, value: "Hello World", want: "hello-world"},
+		{name: "rejected", options: []sluggableOption{WithBlockedWords("Darn")}, value: "Darn it", wantErr: ErrBlockedSlug},
+		{name: "after slugifying", options: []sluggableOption{WithBlockedWords("darn")}, value: "DÄRN!", wantErr: ErrBlockedSlug},
+		{name: "whole words only", options: []sluggableOption{WithBlockedWords("darn")}, value: "Darnell Street", want: "darnell-street"},
+		{name: "multi-word terms", options: []sluggableOption{WithBlockedWords("bad idea")}, value: "A bad idea", wantErr: ErrBlockedSlug},
+		{name: "multi-word terms in order", options: []sluggableOption{WithBlockedWords("bad idea")}, value: "Idea bad", want: "idea-bad"},
+		{name: "default list", options: []sluggableOption{WithBlockedWords(DefaultBlockedWords()...)}, value: "What the fuck", wantErr: ErrBlockedSlug},
+		{
+			name:    "masked",
+			options: []sluggableOption{WithBlockedWords("darn", "bad idea"), WithBlockedWordAction(BlockMask)},
+			value:   "Darn, a bad idea again",
+			want:    "a-again",
+		},
+		{
+			name:    "masked keeps the separators",
+			options: []sluggableOption{WithPreset(EmailLocalPart()), WithBlockedWords("darn"), WithBlockedWordAction(BlockMask)},
+			value:   "Jane Darn Doe",
+			want:    "jane.doe",
+		},
+		{
+			name:    "masked to nothing",
+			options: []sluggableOption{WithBlockedWords("darn"), WithBlockedWordAction(BlockMask), WithUntitled("untitled")},
+			value:   "Darn",
+			want:    "untitled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.options...).options.makeSlug(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("makeSlug(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("makeSlug(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package sluggable
+
+import (
+	"strings"
+)
+
+// maxRedirects stops following historical slugs that redirect in a cycle.
+const maxRedirects = 10
+
+// WithRedirects maps historical slugs to their current slug, used by CanonicalPath. Chains are followed,
+// so "old" → "older" → "current" resolves to "current". Repeated calls add to the redirects.
+func WithRedirects(redirects map[string]string) sluggableOption {
+	return func(opts *options) {
+		// Copy so options sharing the same map aren't changed
+		merged := make(map[string]string, len(opts.redirects)+len(redirects))
+		for from, to := range opts.redirects {
+			merged[from] = to
+		}
+
+		for from, to := range redirects {
+			merged[from] = to
+		}
+
+		opts.redirects = merged
+	}
+}
+
+// CanonicalPath returns the canonical form of a request path: lowercased, without empty segments or a
+// trailing slash, with repeated separators collapsed and historical slugs replaced by the current ones
+// (see WithRedirects). The bool reports whether the path changed, so web frontends know to redirect.
+// Only pass the path, without query string or fragment.
+func (s *Sluggable) CanonicalPath(requestPath string) (string, bool) {
+	segments := strings.Split(requestPath, "/")
+	canonical := make([]string, 0, len(segments))
+
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		canonical = append(canonical, s.options.canonicalSegment(segment))
+	}
+
+	path := "/" + strings.Join(canonical, "/")
+
+	return path, path != requestPath
+}
+
+// canonicalSegment lowercases the segment, collapses repeated separators and follows the redirects.
+func (o options) canonicalSegment(segment string) string {
+	segment = strings.ToLower(segment)
+
+	// Without a separator there is nothing to collapse, and every string contains ""
+	for doubled := o.separator + o.separator; o.separator != "" && strings.Contains(segment, doubled); {
+		segment = strings.ReplaceAll(segment, doubled, o.separator)
+	}
+
+	for i := 0; i < maxRedirects; i++ {
+		current, ok := o.redirects[segment]
+		if !ok || current == segment {
+			break
+		}
+
+		segment = current
+	}
+
+	return segment
+}
+
+// CanonicalPath returns the canonical form of a request path using the global configuration, see Configure.
+func CanonicalPath(requestPath string) (string, bool) {
+	return getGlobal().CanonicalPath(requestPath)
+}
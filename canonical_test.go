@@ -0,0 +1,44 @@
+package sluggable
+
+import "testing"
+
+func TestSluggable_CanonicalPath(t *testing.T) {
+	s := New(WithRedirects(map[string]string{
+		"old-title":   "older-title",
+		"older-title": "current-title",
+		"loop-a":      "loop-b",
+		"loop-b":      "loop-a",
+	}))
+
+	tests := []struct {
+		path        string
+		want        string
+		wantChanged bool
+	}{
+		{path: "/blog/current-title", want: "/blog/current-title"},
+		{path: "/", want: "/"},
+		{path: "", want: "/", wantChanged: true},
+		{path: "/Blog/Current-Title", want: "/blog/current-title", wantChanged: true},
+		{path: "/blog/current-title/", want: "/blog/current-title", wantChanged: true},
+		{path: "//blog///current-title", want: "/blog/current-title", wantChanged: true},
+		{path: "/blog/current--title", want: "/blog/current-title", wantChanged: true},
+		{path: "/blog/old-title", want: "/blog/current-title", wantChanged: true},
+		{path: "/blog/loop-a", want: "/blog/loop-a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, changed := s.CanonicalPath(tt.path)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("Sluggable.CanonicalPath(%q) = %v, %v, want %v, %v", tt.path, got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestCanonicalPathWithoutSeparator(t *testing.T) {
+	got, changed := New(WithSeparator("")).CanonicalPath("/Blog/HelloWorld")
+	if got != "/blog/helloworld" || !changed {
+		t.Errorf("Sluggable.CanonicalPath() = %v, %v, want %v, %v", got, changed, "/blog/helloworld", true)
+	}
+}
@@ -0,0 +1,58 @@
+package sluggable
+
+import (
+	"context"
+	"fmt"
+)
+
+// GenerateWith generates a slug and passes it to insertFn. When insertFn fails with a unique constraint
+// violation of the dialect, e.g. because a concurrent request inserted the same slug first, the slug is
+// treated as taken and the next one is tried, up to WithConflictRetry attempts. Other errors are returned as is.
+//
+// On Postgres a failed statement aborts the transaction, so run insertFn in its own transaction or savepoint.
+func (s *Sluggable) GenerateWith(db contextExecutor, value string, insertFn func(slug string) error, options ...sluggableOption) (string, error) {
+	return s.GenerateWithContext(context.Background(), db, value, insertFn, options...)
+}
+
+// GenerateWithContext is like GenerateWith with a context.
+func (s *Sluggable) GenerateWithContext(
+	ctx context.Context, db contextExecutor, value string, insertFn func(slug string) error, options ...sluggableOption,
+) (string, error) {
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return "", err
+	}
+
+	if err := opts.checkWritable("inserting"); err != nil {
+		return "", err
+	}
+
+	var conflicting []string
+
+	for attempt := 1; ; attempt++ {
+		// Cap the slice so the per attempt option never writes into the caller's array
+		slug, err := s.GenerateContext(ctx, db, value, append(options[:len(options):len(options)], WithReserved(conflicting...))...)
+		if err != nil {
+			return "", err
+		}
+
+		err = opts.injectFault(ctx, FaultInsert)
+		if err == nil {
+			err = insertFn(slug)
+		}
+		if err == nil {
+			return slug, nil
+		}
+
+		if !opts.getDialect().uniqueViolation.matches(err) {
+			return "", err
+		}
+
+		if attempt >= opts.conflictRetry {
+			return "", fmt.Errorf("[sluggable] slug %q still conflicts after %d attempts: %w", slug, attempt, err)
+		}
+
+		// The conflicting row may not be visible to the lookup yet, so exclude the slug explicitly
+		conflicting = append(conflicting, slug)
+	}
+}
@@ -0,0 +1,114 @@
+package sluggable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "insert failed" }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestDialect_UniqueViolation(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		err     error
+		want    bool
+	}{
+		{name: "postgres sqlstate", dialect: Postgres, err: sqlStateError("23505"), want: true},
+		{name: "postgres other sqlstate", dialect: Postgres, err: sqlStateError("23503"), want: false},
+		{name: "postgres message", dialect: Postgres, err: errors.New(`pq: duplicate key value violates unique constraint "posts_slug_key"`), want: true},
+		{name: "mysql", dialect: MySQL, err: errors.New("Error 1062 (23000): Duplicate entry 'hello' for key 'slug'"), want: true},
+		{name: "sqlite", dialect: SQLite, err: errors.New("UNIQUE constraint failed: posts.slug"), want: true},
+		{name: "sqlserver", dialect: SQLServer, err: errors.New("mssql: Cannot insert duplicate key row in object 'dbo.posts'"), want: true},
+		{name: "other error", dialect: Postgres, err: errors.New("connection refused"), want: false},
+		{name: "nil", dialect: Postgres, err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.uniqueViolation.matches(tt.err); got != tt.want {
+				t.Errorf("uniqueViolation.matches(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSluggable_GenerateWith(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	// The conflicting row isn't visible yet, so both lookups find nothing
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "posts"`).
+			WithArgs("hello-world", "hello-world-%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+	}
+
+	var inserted []string
+
+	got, err := New(WithTableName("posts")).GenerateWith(db, "Hello World", func(slug string) error {
+		inserted = append(inserted, slug)
+		if slug == "hello-world" {
+			return sqlStateError("23505")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateWith() error = %v", err)
+	}
+
+	if got != "hello-world-2" || strings.Join(inserted, ",") != "hello-world,hello-world-2" {
+		t.Errorf("Sluggable.GenerateWith() = %v after %v, want hello-world-2 after retrying", got, inserted)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSluggable_GenerateWithErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "posts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+	}
+
+	s := New(WithTableName("posts"))
+
+	attempts := 0
+
+	_, err = s.GenerateWith(db, "Hello World", func(string) error {
+		attempts++
+
+		return sqlStateError("23505")
+	}, WithConflictRetry(2))
+	if err == nil || attempts != 2 || !strings.Contains(err.Error(), "still conflicts after 2 attempts") {
+		t.Errorf("Sluggable.GenerateWith() error = %v after %d attempts, want to give up after 2", err, attempts)
+	}
+
+	insertErr := errors.New("connection reset")
+
+	_, err = s.GenerateWith(db, "Hello World", func(string) error { return insertErr })
+	if !errors.Is(err, insertErr) {
+		t.Errorf("Sluggable.GenerateWith() error = %v, want %v", err, insertErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
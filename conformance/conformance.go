@@ -0,0 +1,63 @@
+// Package conformance ships the expected slugs of every sluggable rules version, so other implementations
+// (services, JavaScript ports, ...) can verify they produce identical output. The corpus is plain JSON
+// in the corpus directory and can be read without Go.
+package conformance
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+//go:embed corpus/*.json
+var corpus embed.FS
+
+// Case is an input and the slug a rules version must produce for it.
+type Case struct {
+	Name  string `json:"name"`
+	Input string `json:"input"`
+	Want  string `json:"want"`
+}
+
+// Cases returns the corpus of the rules version.
+func Cases(version int) ([]Case, error) {
+	data, err := corpus.ReadFile(fmt.Sprintf("corpus/v%d.json", version))
+	if err != nil {
+		return nil, fmt.Errorf("[sluggable] no conformance corpus for rules version %d: %w", version, err)
+	}
+
+	var cases []Case
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to decode conformance corpus v%d: %w", version, err)
+	}
+
+	return cases, nil
+}
+
+// Run checks slug against every case of the rules version, e.g.
+//
+//	conformance.Run(t, 1, func(input string) (string, error) {
+//		return mySlugger.Generate(nil, input, sluggable.WithAvailabilityChecker(alwaysAvailable))
+//	})
+func Run(t *testing.T, version int, slug func(input string) (string, error)) {
+	t.Helper()
+
+	cases, err := Cases(version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := slug(tc.Input)
+			if err != nil {
+				t.Fatalf("slug(%q) error = %v", tc.Input, err)
+			}
+
+			if got != tc.Want {
+				t.Errorf("slug(%q) = %v, want %v", tc.Input, got, tc.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package sluggable
+
+import (
+	"strings"
+)
+
+// confusables maps Cyrillic, Greek and other homoglyphs to the Latin letter they imitate.
+//
+//nolint:gochecknoglobals
+var confusables = strings.NewReplacer(
+	// Cyrillic lowercase
+	"а", "a", "в", "b", "е", "e", "к", "k", "м", "m", "н", "h", "о", "o", "р", "p",
+	"с", "c", "т", "t", "у", "y", "х", "x", "ѕ", "s", "і", "i", "ј", "j", "ԁ", "d",
+	"ԛ", "q", "ԝ", "w", "һ", "h", "ӏ", "l",
+	// Cyrillic uppercase
+	"А", "A", "В", "B", "Е", "E", "К", "K", "М", "M", "Н", "H", "О", "O", "Р", "P",
+	"С", "C", "Т", "T", "У", "Y", "Х", "X", "Ѕ", "S", "І", "I", "Ј", "J", "Ԛ", "Q", "Ԝ", "W",
+	// Greek lowercase
+	"α", "a", "ο", "o", "ρ", "p", "ν", "v", "τ", "t", "κ", "k", "ι", "i", "υ", "u",
+	// Greek uppercase
+	"Α", "A", "Β", "B", "Ε", "E", "Ζ", "Z", "Η", "H", "Ι", "I", "Κ", "K", "Μ", "M",
+	"Ν", "N", "Ο", "O", "Ρ", "P", "Τ", "T", "Υ", "Y", "Χ", "X",
+	// Latin lookalikes of i
+	"ı", "i",
+	// Fullwidth and mathematical lookalikes
+	"ａ", "a", "ｅ", "e", "ｏ", "o",
+)
+
+// foldConfusables replaces homoglyphs with their Latin lookalike so spoofed values produce the same slug.
+func foldConfusables(value string) string {
+	return confusables.Replace(value)
+}
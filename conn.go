@@ -0,0 +1,49 @@
+package sluggable
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// Conn pins queries to a single connection of the pool, so session state like SET search_path or
+// session advisory locks applies to every query of a sequence. Pass it wherever a db is accepted.
+type Conn struct {
+	*sql.Conn
+
+	db *sql.DB
+}
+
+func (c Conn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+func (c Conn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+func (c Conn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+// Driver exposes the driver of the pool, so the dialect is still detected.
+func (c Conn) Driver() driver.Driver {
+	return c.db.Driver()
+}
+
+// UseConn checks out one connection of the pool, runs fn on it and returns the connection to the pool,
+// also when fn panics. Use it when session settings like search_path must apply to the lookup and the
+// write-back, since consecutive calls on a *sql.DB may each use another connection. WithAdvisoryLock and
+// WithStatementTimeout are transaction scoped, a pinned connection doesn't keep them between statements;
+// use a *sql.Tx (conn.BeginTx) for those. Session settings stay on the connection after it's returned,
+// reset them in fn (e.g. RESET search_path) when other code shares the pool.
+func UseConn(ctx context.Context, db *sql.DB, fn func(conn Conn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("[sluggable] failed to check out connection: %w", err)
+	}
+	defer conn.Close()
+
+	return fn(Conn{Conn: conn, db: db})
+}
@@ -0,0 +1,54 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestUseConn(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(`SET search_path TO tenant_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow(1, "hello-world"))
+
+	var got string
+
+	err = UseConn(context.Background(), db, func(conn Conn) error {
+		if _, err := conn.Exec(`SET search_path TO tenant_1`); err != nil {
+			return err
+		}
+
+		got, err = New().Generate(conn, "Hello World", WithTableName("articles"))
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("UseConn() error = %v", err)
+	}
+
+	if got != "hello-world-2" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-2")
+	}
+
+	if inUse := db.Stats().InUse; inUse != 0 {
+		t.Errorf("UseConn() left %v connections in use, want 0", inUse)
+	}
+
+	wantErr := errors.New("failed")
+	if err := UseConn(context.Background(), db, func(Conn) error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("UseConn() error = %v, want %v", err, wantErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
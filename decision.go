@@ -0,0 +1,101 @@
+package sluggable
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Decision records how a slug was chosen, so "why did this URL get -7?" can be answered weeks later.
+type Decision struct {
+	Time        time.Time `json:"time"`
+	Input       string    `json:"input"`
+	Identifier  string    `json:"identifier,omitempty"`
+	OptionsHash string    `json:"options_hash"` // Changes whenever the configuration changes, the identifier aside
+	Taken       []string  `json:"taken"`        // Similar slugs found, sorted
+	Tried       []string  `json:"tried"`        // Candidate slugs in the order they were tried
+	Slug        string    `json:"slug"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// WithDecisionLog passes a Decision to log after every generation, failed ones included. Previews aren't
+// logged. Write it to a table or use DecisionLogWriter for JSON lines.
+func WithDecisionLog(log func(ctx context.Context, decision Decision)) sluggableOption {
+	return func(opts *options) {
+		opts.decisionLog = log
+	}
+}
+
+// DecisionLogWriter returns a decision log writing one JSON object per line to w. Write errors are ignored,
+// logging never fails a generation.
+func DecisionLogWriter(w io.Writer) func(ctx context.Context, decision Decision) {
+	var mutex sync.Mutex
+
+	return func(_ context.Context, decision Decision) {
+		line, err := json.Marshal(decision)
+		if err != nil {
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		_, _ = w.Write(append(line, '\n'))
+	}
+}
+
+// decisionTrace collects the slugs of a generation for the decision log, nil when decisions aren't logged.
+type decisionTrace struct {
+	taken []string
+	tried []string
+}
+
+// found records the similar slugs of the last lookup, a shortened base is looked up again.
+func (t *decisionTrace) found(slugs []string) {
+	if t != nil {
+		t.taken = append([]string(nil), slugs...)
+	}
+}
+
+func (t *decisionTrace) try(slug string) {
+	if t != nil {
+		t.tried = append(t.tried, slug)
+	}
+}
+
+func (o options) logDecision(ctx context.Context, value string, result Result, err error) {
+	taken := o.trace.taken
+	sort.Strings(taken)
+
+	decision := Decision{
+		Time:        time.Now(),
+		Input:       value,
+		Identifier:  identifierString(o.identifier),
+		OptionsHash: o.hash(),
+		Taken:       taken,
+		Tried:       o.trace.tried,
+		Slug:        result.Slug,
+	}
+
+	if err != nil {
+		decision.Error = err.Error()
+	}
+
+	o.decisionLog(ctx, decision)
+}
+
+// hash fingerprints the configuration without the per record identifier.
+func (o options) hash() string {
+	snapshot := o.snapshot()
+	snapshot.Identifier = nil
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%#v", snapshot)))
+
+	return hex.EncodeToString(sum[:8])
+}
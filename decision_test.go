@@ -0,0 +1,88 @@
+package sluggable
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithDecisionLog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow(1, "hello-world-2").AddRow(2, "hello-world"))
+
+	var decisions []Decision
+
+	s := New(WithDecisionLog(func(ctx context.Context, decision Decision) {
+		decisions = append(decisions, decision)
+	}), WithReserved("hello-world-3"))
+
+	got, err := s.Generate(db, "Hello World", WithTableName("articles"), WithIdentifier(7))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if _, err := s.Generate(db, "Hello World", WithTableName("articles"), WithPreview()); err == nil {
+		t.Fatalf("Sluggable.Generate() error = nil, want unexpected query error")
+	}
+
+	if len(decisions) != 1 {
+		t.Fatalf("decision log calls = %v, want 1, previews aren't logged", len(decisions))
+	}
+
+	decision := decisions[0]
+	if decision.Input != "Hello World" || decision.Identifier != "7" || decision.Slug != got {
+		t.Errorf("Decision = %+v, want input, identifier and slug %v", decision, got)
+	}
+
+	if want := []string{"hello-world", "hello-world-2"}; !reflect.DeepEqual(decision.Taken, want) {
+		t.Errorf("Decision.Taken = %v, want %v", decision.Taken, want)
+	}
+
+	if want := []string{"hello-world-3", "hello-world-4"}; !reflect.DeepEqual(decision.Tried, want) {
+		t.Errorf("Decision.Tried = %v, want %v", decision.Tried, want)
+	}
+
+	if decision.OptionsHash == "" {
+		t.Errorf("Decision.OptionsHash is empty")
+	}
+
+	if New().options.hash() == New(WithSeparator("_")).options.hash() {
+		t.Errorf("options.hash() is the same for different configurations")
+	}
+
+	if New(WithIdentifier(1)).options.hash() != New(WithIdentifier(2)).options.hash() {
+		t.Errorf("options.hash() differs by identifier")
+	}
+}
+
+func TestDecisionLogWriter(t *testing.T) {
+	var buffer bytes.Buffer
+
+	s := New(WithDecisionLog(DecisionLogWriter(&buffer)), WithAvailabilityChecker(func(ctx context.Context, slug string) (bool, error) {
+		return slug != "hello-world", nil
+	}))
+
+	if _, err := s.Generate(nil, "Hello World"); err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	var decision Decision
+	if err := json.Unmarshal(bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), &decision); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if decision.Slug != "hello-world-2" || len(decision.Tried) != 2 {
+		t.Errorf("Decision = %+v, want slug hello-world-2 after 2 tries", decision)
+	}
+}
@@ -0,0 +1,193 @@
+package sluggable
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LockMode is the row locking used when looking up similar slugs inside a transaction.
+type LockMode int
+
+const (
+	NoLock LockMode = iota
+	LockForUpdate
+	LockForShare
+)
+
+// Dialect describes the placeholder format, identifier quoting and row locking of a database.
+type Dialect struct {
+	name        string
+	placeholder func(index int) string
+	quote       func(identifier string) string
+	lockHint    map[LockMode]string // Appended to the table name
+	lockSuffix  map[LockMode]string // Appended to the query
+	limitPrefix string              // Row limit format placed after SELECT
+	limitSuffix string              // Row limit format placed before the lock suffix
+
+	withRecursive string // Starts recursive common table expressions
+	likeWildcards string // Characters with a meaning in LIKE patterns, including a default escape character
+
+	uniqueViolation uniqueViolation
+}
+
+// uniqueViolation recognizes unique constraint errors by SQLSTATE/error number or by the driver's message,
+// so no driver has to be imported.
+type uniqueViolation struct {
+	sqlState string
+	messages []string
+}
+
+func (u uniqueViolation) matches(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var withSQLState interface{ SQLState() string }
+	if u.sqlState != "" && errors.As(err, &withSQLState) && withSQLState.SQLState() == u.sqlState {
+		return true
+	}
+
+	for _, message := range u.messages {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	Postgres = Dialect{
+		name:          "postgres",
+		placeholder:   func(index int) string { return fmt.Sprintf("$%d", index) },
+		quote:         func(identifier string) string { return `"` + identifier + `"` },
+		lockSuffix:    map[LockMode]string{LockForUpdate: " FOR UPDATE", LockForShare: " FOR SHARE"},
+		limitSuffix:   " LIMIT %d",
+		withRecursive: "WITH RECURSIVE",
+		likeWildcards: `%_\`, // Backslash is the default LIKE escape
+		uniqueViolation: uniqueViolation{
+			sqlState: "23505",
+			messages: []string{"duplicate key value violates unique constraint", "SQLSTATE 23505"},
+		},
+	}
+	MySQL = Dialect{
+		name:          "mysql",
+		placeholder:   func(int) string { return "?" },
+		quote:         func(identifier string) string { return "`" + identifier + "`" },
+		lockSuffix:    map[LockMode]string{LockForUpdate: " FOR UPDATE", LockForShare: " LOCK IN SHARE MODE"},
+		limitSuffix:   " LIMIT %d",
+		withRecursive: "WITH RECURSIVE",
+		likeWildcards: `%_\`, // Backslash is the default LIKE escape
+		uniqueViolation: uniqueViolation{
+			messages: []string{"Error 1062", "Duplicate entry"},
+		},
+	}
+	// SQLite locks the whole database for writing transactions, so there are no row locks
+	SQLite = Dialect{
+		name:          "sqlite",
+		placeholder:   func(int) string { return "?" },
+		quote:         func(identifier string) string { return `"` + identifier + `"` },
+		limitSuffix:   " LIMIT %d",
+		withRecursive: "WITH RECURSIVE",
+		likeWildcards: "%_",
+		uniqueViolation: uniqueViolation{
+			messages: []string{"UNIQUE constraint failed", "constraint failed: UNIQUE"},
+		},
+	}
+	SQLServer = Dialect{
+		name:          "sqlserver",
+		placeholder:   func(index int) string { return fmt.Sprintf("@p%d", index) },
+		quote:         func(identifier string) string { return "[" + identifier + "]" },
+		lockHint:      map[LockMode]string{LockForUpdate: " WITH (UPDLOCK, HOLDLOCK)", LockForShare: " WITH (HOLDLOCK)"},
+		limitPrefix:   "TOP (%d) ",
+		withRecursive: "WITH",
+		likeWildcards: "%_[",
+		uniqueViolation: uniqueViolation{
+			messages: []string{"Cannot insert duplicate key", "Violation of UNIQUE KEY constraint", "Violation of PRIMARY KEY constraint"},
+		},
+	}
+)
+
+func (d Dialect) String() string {
+	return d.name
+}
+
+// limit returns the clauses limiting a query to n rows, one of them empty.
+func (d Dialect) limit(n int) (string, string) {
+	prefix, suffix := "", ""
+	if d.limitPrefix != "" {
+		prefix = fmt.Sprintf(d.limitPrefix, n)
+	}
+
+	if d.limitSuffix != "" {
+		suffix = fmt.Sprintf(d.limitSuffix, n)
+	}
+
+	return prefix, suffix
+}
+
+//nolint:gochecknoglobals
+var driverDialects = []struct {
+	names    []string
+	packages []string
+	dialect  Dialect
+}{
+	{names: []string{"postgres", "pgx", "pq"}, packages: []string{"github.com/lib/pq", "github.com/jackc/pgx"}, dialect: Postgres},
+	{names: []string{"mysql"}, packages: []string{"github.com/go-sql-driver/mysql"}, dialect: MySQL},
+	{names: []string{"sqlite3", "sqlite"}, packages: []string{"github.com/mattn/go-sqlite3", "modernc.org/sqlite"}, dialect: SQLite},
+	{names: []string{"sqlserver", "mssql"}, packages: []string{"github.com/microsoft/go-mssqldb", "github.com/denisenkom/go-mssqldb"}, dialect: SQLServer},
+}
+
+func dialectFromDriverName(name string) (Dialect, bool) {
+	name = strings.ToLower(name)
+
+	for _, candidate := range driverDialects {
+		for _, candidateName := range candidate.names {
+			if name == candidateName {
+				return candidate.dialect, true
+			}
+		}
+	}
+
+	return Dialect{}, false
+}
+
+func dialectFromPackage(pkgPath string) (Dialect, bool) {
+	for _, candidate := range driverDialects {
+		for _, candidatePackage := range candidate.packages {
+			if strings.HasPrefix(pkgPath, candidatePackage) {
+				return candidate.dialect, true
+			}
+		}
+	}
+
+	return Dialect{}, false
+}
+
+// detectDialect picks a dialect from the configured driver name or, for a *sql.DB, the registered driver.
+// Falls back to Postgres when nothing matches.
+func detectDialect(db contextExecutor, driverName string) Dialect {
+	if driverName != "" {
+		if dialect, ok := dialectFromDriverName(driverName); ok {
+			return dialect
+		}
+	}
+
+	if withDriver, ok := db.(interface{ Driver() driver.Driver }); ok {
+		driverType := reflect.TypeOf(withDriver.Driver())
+		if driverType != nil && driverType.Kind() == reflect.Ptr {
+			driverType = driverType.Elem()
+		}
+
+		if driverType != nil {
+			if dialect, ok := dialectFromPackage(driverType.PkgPath()); ok {
+				return dialect
+			}
+		}
+	}
+
+	return Postgres
+}
@@ -0,0 +1,561 @@
+package sluggable
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name:    "postgres",
+			dialect: Postgres,
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`,
+		},
+		{
+			name:    "mysql",
+			dialect: MySQL,
+			want:    "SELECT `id`, `slug` FROM `articles` WHERE (`slug` = ? OR `slug` LIKE ?) AND (`deleted_at` IS NULL)",
+		},
+		{
+			name:    "sqlite",
+			dialect: SQLite,
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = ? OR "slug" LIKE ?) AND ("deleted_at" IS NULL)`,
+		},
+		{
+			name:    "sqlserver",
+			dialect: SQLServer,
+			want:    `SELECT [id], [slug] FROM [articles] WHERE ([slug] = @p1 OR [slug] LIKE @p2) AND ([deleted_at] IS NULL)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(tt.want).
+				WithArgs("hello-world", "hello-world-%").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+			s := New(WithDialect(tt.dialect))
+			if _, err := s.Generate(db, "Hello World", WithTableName("articles")); err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDetectDialect(t *testing.T) {
+	t.Run("driver names", func(t *testing.T) {
+		tests := map[string]Dialect{
+			"postgres":  Postgres,
+			"pgx":       Postgres,
+			"MySQL":     MySQL,
+			"sqlite3":   SQLite,
+			"sqlite":    SQLite,
+			"sqlserver": SQLServer,
+			"mssql":     SQLServer,
+		}
+
+		for name, want := range tests {
+			got, ok := dialectFromDriverName(name)
+			if !ok || got.name != want.name {
+				t.Errorf("dialectFromDriverName(%q) = %v, want %v", name, got, want)
+			}
+		}
+
+		if _, ok := dialectFromDriverName("oracle"); ok {
+			t.Error("dialectFromDriverName() should not match unknown drivers")
+		}
+	})
+
+	t.Run("driver packages", func(t *testing.T) {
+		tests := map[string]Dialect{
+			"github.com/lib/pq":                Postgres,
+			"github.com/jackc/pgx/v5/stdlib":   Postgres,
+			"github.com/go-sql-driver/mysql":   MySQL,
+			"github.com/mattn/go-sqlite3":      SQLite,
+			"modernc.org/sqlite":               SQLite,
+			"github.com/microsoft/go-mssqldb":  SQLServer,
+			"github.com/denisenkom/go-mssqldb": SQLServer,
+		}
+
+		for pkgPath, want := range tests {
+			got, ok := dialectFromPackage(pkgPath)
+			if !ok || got.name != want.name {
+				t.Errorf("dialectFromPackage(%q) = %v, want %v", pkgPath, got, want)
+			}
+		}
+	})
+
+	t.Run("falls back to postgres", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		if got := detectDialect(db, ""); got.name != Postgres.name {
+			t.Errorf("detectDialect() = %v, want %v", got, Postgres)
+		}
+	})
+}
+
+func TestWithDriverName(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT `id`, `slug` FROM `articles` WHERE (`slug` = ? OR `slug` LIKE ?) AND (`deleted_at` IS NULL)").
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	s := New(WithDriverName("mysql"))
+	if _, err := s.Generate(db, "Hello World", WithTableName("articles")); err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		schema  string
+		want    string
+	}{
+		{
+			name:    "postgres",
+			dialect: Postgres,
+			schema:  "cms",
+			want:    `SELECT "id", "slug" FROM "cms"."articles" WHERE ("slug" = $1 OR "slug" LIKE $2)`,
+		},
+		{
+			name:    "sqlserver",
+			dialect: SQLServer,
+			schema:  "dbo",
+			want:    `SELECT [id], [slug] FROM [dbo].[articles] WHERE ([slug] = @p1 OR [slug] LIKE @p2)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(tt.want).
+				WithArgs("hello-world", "hello-world-%").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+			s := New(WithDialect(tt.dialect), WithDeleted())
+			if _, err := s.Generate(db, "Hello World", WithSchema(tt.schema), WithTableName("articles")); err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestSluggable_GenerateInTx(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		want    string
+	}{
+		{
+			name: "postgres for update",
+			want: `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) FOR UPDATE`,
+		},
+		{
+			name:    "postgres for share",
+			options: []sluggableOption{WithLock(LockForShare)},
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) FOR SHARE`,
+		},
+		{
+			name:    "mysql",
+			options: []sluggableOption{WithDriverName("mysql")},
+			want:    "SELECT `id`, `slug` FROM `articles` WHERE (`slug` = ? OR `slug` LIKE ?) FOR UPDATE",
+		},
+		{
+			name:    "sqlite has no row locks",
+			options: []sluggableOption{WithDialect(SQLite)},
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = ? OR "slug" LIKE ?)`,
+		},
+		{
+			name:    "sqlserver table hint",
+			options: []sluggableOption{WithDialect(SQLServer)},
+			want:    `SELECT [id], [slug] FROM [articles] WITH (UPDLOCK, HOLDLOCK) WHERE ([slug] = @p1 OR [slug] LIKE @p2)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(tt.want).
+				WithArgs("hello-world", "hello-world-%").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+			mock.ExpectCommit()
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatalf("Failed to begin transaction: %v", err)
+			}
+
+			s := New(WithDeleted())
+			if _, err := s.GenerateInTx(tx, "Hello World", append(tt.options, WithTableName("articles"))...); err != nil {
+				t.Fatalf("Sluggable.GenerateInTx() error = %v", err)
+			}
+
+			if err := tx.Commit(); err != nil {
+				t.Fatalf("Failed to commit transaction: %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWithAdvisoryLock(t *testing.T) {
+	t.Run("locks the base slug before the lookup", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock(hashtext($1))").
+			WithArgs("hello-world").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+			WithArgs("hello-world", "hello-world-%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		defer tx.Rollback()
+
+		if _, err := New(WithAdvisoryLock()).Generate(tx, "Hello World", WithTableName("articles")); err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("not for previews", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+			WithArgs("hello-world", "hello-world-%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+		if _, err := New(WithStatementTimeout(time.Second)).Generate(db, "Hello World", WithTableName("articles"), WithPreview()); err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("only on postgres", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		_, err = New(WithAdvisoryLock(), WithDialect(MySQL)).Generate(db, "Hello World", WithTableName("articles"))
+		if err == nil || !strings.Contains(err.Error(), "only supported on postgres") {
+			t.Errorf("Sluggable.Generate() error = %v, want unsupported dialect error", err)
+		}
+	})
+}
+
+func TestWithStatementTimeout(t *testing.T) {
+	t.Run("sets the timeout before the lookup", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT current_setting('statement_timeout')").
+			WillReturnRows(sqlmock.NewRows([]string{"current_setting"}).AddRow("30s"))
+		mock.ExpectExec("SET LOCAL statement_timeout = 2500").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+			WithArgs("hello-world", "hello-world-%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+		mock.ExpectExec("SELECT set_config('statement_timeout', $1, true)").
+			WithArgs("30s").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		defer tx.Rollback()
+
+		if _, err := New(WithStatementTimeout(2500*time.Millisecond)).Generate(tx, "Hello World", WithTableName("articles")); err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("only on postgres", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		_, err = New(WithStatementTimeout(time.Second), WithDialect(SQLite)).Generate(db, "Hello World", WithTableName("articles"))
+		if err == nil || !strings.Contains(err.Error(), "only supported on postgres") {
+			t.Errorf("Sluggable.Generate() error = %v, want unsupported dialect error", err)
+		}
+	})
+}
+
+func TestWithScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		options  []sluggableOption
+		want     string
+		wantArgs []any
+	}{
+		{
+			name:     "postgres",
+			dialect:  Postgres,
+			options:  []sluggableOption{WithScope("user_id", 123), WithScope("category", "news")},
+			want:     `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("user_id" = $3) AND ("category" = $4)`,
+			wantArgs: []any{"hello-world", "hello-world-%", 123, "news"},
+		},
+		{
+			name:     "mysql",
+			dialect:  MySQL,
+			options:  []sluggableOption{WithScope("user_id", 123)},
+			want:     "SELECT `id`, `slug` FROM `articles` WHERE (`slug` = ? OR `slug` LIKE ?) AND (`deleted_at` IS NULL) AND (`user_id` = ?)",
+			wantArgs: []any{"hello-world", "hello-world-%", 123},
+		},
+		{
+			name:    "after where clauses",
+			dialect: SQLServer,
+			options: []sluggableOption{WithScope("user_id", 123), WithWhere("[status] = ?", "published")},
+			want: `SELECT [id], [slug] FROM [articles] WHERE ([slug] = @p1 OR [slug] LIKE @p2) AND ([deleted_at] IS NULL) ` +
+				`AND ([status] = @p3) AND ([user_id] = @p4)`,
+			wantArgs: []any{"hello-world", "hello-world-%", "published", 123},
+		},
+		{
+			name:     "replaced per call",
+			dialect:  Postgres,
+			options:  []sluggableOption{WithScope("user_id", 123), WithScope("user_id", 456)},
+			want:     `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("user_id" = $3)`,
+			wantArgs: []any{"hello-world", "hello-world-%", 456},
+		},
+		{
+			name:     "nil",
+			dialect:  Postgres,
+			options:  []sluggableOption{WithScope("user_id", nil)},
+			want:     `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("user_id" IS NULL)`,
+			wantArgs: []any{"hello-world", "hello-world-%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			args := make([]driver.Value, len(tt.wantArgs))
+			for i, arg := range tt.wantArgs {
+				args[i] = arg
+			}
+
+			mock.ExpectQuery(tt.want).
+				WithArgs(args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+			s := New(WithDialect(tt.dialect))
+			if _, err := s.Generate(db, "Hello World", append(tt.options, WithTableName("articles"))...); err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	t.Run("invalid column", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		_, err = New(WithScope(`user_id"; --`, 1)).Generate(db, "Hello World", WithTableName("articles"))
+		if !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("Sluggable.Generate() error = %v, want %v", err, ErrInvalidIdentifier)
+		}
+	})
+}
+
+func TestStructuredWhere(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		options  []sluggableOption
+		want     string
+		wantArgs []driver.Value
+	}{
+		{
+			name:     "in on postgres",
+			dialect:  Postgres,
+			options:  []sluggableOption{WithWhereIn("status", "published", "draft")},
+			want:     `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("status" IN ($3, $4))`,
+			wantArgs: []driver.Value{"hello-world", "hello-world-%", "published", "draft"},
+		},
+		{
+			name:     "in on mysql",
+			dialect:  MySQL,
+			options:  []sluggableOption{WithWhereIn("status", "published")},
+			want:     "SELECT `id`, `slug` FROM `articles` WHERE (`slug` = ? OR `slug` LIKE ?) AND (`deleted_at` IS NULL) AND (`status` IN (?))",
+			wantArgs: []driver.Value{"hello-world", "hello-world-%", "published"},
+		},
+		{
+			name:     "without values",
+			dialect:  Postgres,
+			options:  []sluggableOption{WithWhereIn("status")},
+			want:     `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND (1 = 0)`,
+			wantArgs: []driver.Value{"hello-world", "hello-world-%"},
+		},
+		{
+			name:    "null checks on sqlserver",
+			dialect: SQLServer,
+			options: []sluggableOption{WithWhereNull("archived_at"), WithWhereNotNull("published_at"), WithWhereIn("locale", "en")},
+			want: `SELECT [id], [slug] FROM [articles] WHERE ([slug] = @p1 OR [slug] LIKE @p2) AND ([deleted_at] IS NULL) ` +
+				`AND ([archived_at] IS NULL) AND ([published_at] IS NOT NULL) AND ([locale] IN (@p3))`,
+			wantArgs: []driver.Value{"hello-world", "hello-world-%", "en"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(tt.want).
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+			s := New(WithDialect(tt.dialect))
+			if _, err := s.Generate(db, "Hello World", append(tt.options, WithTableName("articles"))...); err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	t.Run("invalid column", func(t *testing.T) {
+		if _, _, err := New(WithWhereNull(`status" OR 1=1 --`)).SimilarWhere("hello-world"); !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("Sluggable.SimilarWhere() error = %v, want %v", err, ErrInvalidIdentifier)
+		}
+	})
+}
+
+func TestLikeEscaping(t *testing.T) {
+	method := func(value, separator string) string { return value }
+
+	tests := []struct {
+		name        string
+		dialect     Dialect
+		value       string
+		wantPattern string
+		wantEscape  bool
+	}{
+		{name: "plain", dialect: Postgres, value: "hello-world", wantPattern: "hello-world-%"},
+		{name: "percent and underscore", dialect: Postgres, value: "100%_off", wantPattern: "100!%!_off-%", wantEscape: true},
+		{name: "escape character", dialect: MySQL, value: "wow!", wantPattern: "wow!!-%", wantEscape: true},
+		{name: "brackets on sqlserver", dialect: SQLServer, value: "[draft]", wantPattern: "![draft]-%", wantEscape: true},
+		{name: "brackets elsewhere", dialect: SQLite, value: "[draft]", wantPattern: "[draft]-%"},
+		{name: "backslash on postgres", dialect: Postgres, value: `a\b`, wantPattern: `a!\b-%`, wantEscape: true},
+		{name: "backslash on mysql", dialect: MySQL, value: `a\b`, wantPattern: `a!\b-%`, wantEscape: true},
+		{name: "backslash elsewhere", dialect: SQLite, value: `a\b`, wantPattern: `a\b-%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate, params, err := New(WithDialect(tt.dialect), WithMethod(method)).CollisionPredicate(tt.value)
+			if err != nil {
+				t.Fatalf("Sluggable.CollisionPredicate() error = %v", err)
+			}
+
+			if params[1] != tt.wantPattern {
+				t.Errorf("Sluggable.CollisionPredicate() pattern = %v, want %v", params[1], tt.wantPattern)
+			}
+
+			if got := strings.Contains(predicate, " ESCAPE '!'"); got != tt.wantEscape {
+				t.Errorf("Sluggable.CollisionPredicate() = %v, want ESCAPE clause %v", predicate, tt.wantEscape)
+			}
+		})
+	}
+}
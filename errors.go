@@ -0,0 +1,22 @@
+package sluggable
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidIdentifier      = errors.New("[sluggable] invalid identifier")
+	ErrCollisionLimitExceeded = errors.New("[sluggable] collision limit exceeded")
+	ErrSlugNotFound           = errors.New("[sluggable] slug not found")
+	ErrTooManyCandidates      = errors.New("[sluggable] too many candidate rows")
+	ErrSlugTooShort           = errors.New("[sluggable] slug is empty or too short")
+	ErrChangeNotApproved      = errors.New("[sluggable] slug change not approved")
+	ErrSlugsFrozen            = errors.New("[sluggable] slug changes are frozen")
+	ErrBlockedSlug            = errors.New("[sluggable] slug contains a blocked word")
+	ErrNumericOnlySlug        = errors.New("[sluggable] slug is numeric only")
+	ErrConstraintViolation    = errors.New("[sluggable] slug doesn't match the constraint")
+	ErrInvalidSlug            = errors.New("[sluggable] invalid slug")
+	ErrTenantRequired         = errors.New("[sluggable] tenant required")
+	ErrReadOnly               = errors.New("[sluggable] read-only mode")
+	ErrInputTooLong           = errors.New("[sluggable] input too long")
+)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/gonstruct/sluggable"
@@ -39,3 +40,15 @@ func (d *DatabaseModel) OnUpdate(db *sql.DB) (err error) {
 
 	return err
 }
+
+func (d *DatabaseModel) Rename(ctx context.Context, db *sql.DB, name string) (err error) {
+	d.Name = name
+
+	// Generates the slug and runs UPDATE database_models SET slug = ? WHERE id = ?
+	d.Slug, err = globalSlugger.GenerateAndSave(ctx, db, d.Name,
+		sluggable.WithTableName("database_models"),
+		sluggable.WithIdentifier(d.ID),
+	)
+
+	return err
+}
@@ -0,0 +1,60 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// FaultPoint is an operation a FaultInjector can disturb.
+type FaultPoint string
+
+const (
+	FaultLookup FaultPoint = "lookup" // Looking up similar slugs
+	FaultSave   FaultPoint = "save"   // Storing the slug in GenerateAndSave
+	FaultInsert FaultPoint = "insert" // Calling the insert function of GenerateWith
+)
+
+// Fault is what happens at a fault point. The zero Fault runs the operation undisturbed.
+type Fault struct {
+	Delay time.Duration // Waits before the operation, or until the context is done
+	Err   error         // Returned instead of running the operation, see DuplicateKeyError
+}
+
+// FaultInjector decides the fault of every operation, e.g. failing a share of the lookups at random.
+type FaultInjector func(ctx context.Context, point FaultPoint) Fault
+
+// WithFaultInjector disturbs lookups, saves and inserts to verify retry and degradation settings. Faults are
+// only injected in builds with the sluggable_chaos tag (go test -tags sluggable_chaos), other builds ignore
+// the injector, so it can't take down production by accident.
+func WithFaultInjector(injector FaultInjector) sluggableOption {
+	return func(opts *options) {
+		opts.faultInjector = injector
+	}
+}
+
+// DuplicateKeyError returns an error the dialect's unique violation detection matches, to simulate another
+// request inserting the same slug first.
+func (d Dialect) DuplicateKeyError() error {
+	if len(d.uniqueViolation.messages) == 0 {
+		return errors.New("[sluggable] injected duplicate key")
+	}
+
+	return errors.New("[sluggable] injected: " + d.uniqueViolation.messages[0])
+}
+
+// applyFault waits for the delay of the fault and returns its error.
+func applyFault(ctx context.Context, fault Fault) error {
+	if fault.Delay > 0 {
+		timer := time.NewTimer(fault.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return fault.Err
+}
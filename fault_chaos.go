@@ -0,0 +1,16 @@
+//go:build sluggable_chaos
+
+package sluggable
+
+import (
+	"context"
+)
+
+// injectFault applies the fault the injector chooses for the point.
+func (o options) injectFault(ctx context.Context, point FaultPoint) error {
+	if o.faultInjector == nil {
+		return nil
+	}
+
+	return applyFault(ctx, o.faultInjector(ctx, point))
+}
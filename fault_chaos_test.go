@@ -0,0 +1,94 @@
+//go:build sluggable_chaos
+
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithFaultInjector(t *testing.T) {
+	t.Run("lookup errors", func(t *testing.T) {
+		injected := errors.New("connection reset")
+
+		s := New(WithFaultInjector(func(ctx context.Context, point FaultPoint) Fault {
+			return Fault{Err: injected}
+		}))
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		if _, err := s.Generate(db, "Hello World", WithTableName("articles")); !errors.Is(err, injected) {
+			t.Errorf("Sluggable.Generate() error = %v, want %v", err, injected)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("slow lookups", func(t *testing.T) {
+		s := New(WithFaultInjector(func(ctx context.Context, point FaultPoint) Fault {
+			return Fault{Delay: time.Hour}
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		db, _, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		if _, err := s.GenerateContext(ctx, db, "Hello World", WithTableName("articles")); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Sluggable.GenerateContext() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("duplicate key races", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		for i := 0; i < 2; i++ {
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "posts"`).
+				WithArgs("hello-world", "hello-world-%").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+		}
+
+		inserts := 0
+
+		s := New(WithTableName("posts"), WithFaultInjector(func(ctx context.Context, point FaultPoint) Fault {
+			if point == FaultInsert && inserts == 0 {
+				inserts++
+
+				return Fault{Err: Postgres.DuplicateKeyError()}
+			}
+
+			return Fault{}
+		}))
+
+		got, err := s.GenerateWith(db, "Hello World", func(slug string) error { return nil })
+		if err != nil {
+			t.Fatalf("Sluggable.GenerateWith() error = %v", err)
+		}
+
+		if got != "hello-world-2" {
+			t.Errorf("Sluggable.GenerateWith() = %v, want %v", got, "hello-world-2")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+}
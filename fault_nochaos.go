@@ -0,0 +1,12 @@
+//go:build !sluggable_chaos
+
+package sluggable
+
+import (
+	"context"
+)
+
+// injectFault never injects faults outside of sluggable_chaos builds.
+func (o options) injectFault(context.Context, FaultPoint) error {
+	return nil
+}
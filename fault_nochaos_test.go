@@ -0,0 +1,21 @@
+//go:build !sluggable_chaos
+
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithFaultInjector_IgnoredWithoutTag(t *testing.T) {
+	s := New(WithFaultInjector(func(ctx context.Context, point FaultPoint) Fault {
+		return Fault{Err: errors.New("injected")}
+	}), WithAvailabilityChecker(func(ctx context.Context, slug string) (bool, error) {
+		return true, nil
+	}))
+
+	if _, err := s.GenerateWith(nil, "Hello World", func(slug string) error { return nil }); err != nil {
+		t.Errorf("Sluggable.GenerateWith() error = %v, want faults ignored outside sluggable_chaos builds", err)
+	}
+}
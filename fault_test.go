@@ -0,0 +1,28 @@
+package sluggable
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDialect_DuplicateKeyError(t *testing.T) {
+	for _, dialect := range []Dialect{Postgres, MySQL, SQLite, SQLServer} {
+		if err := dialect.DuplicateKeyError(); !dialect.uniqueViolation.matches(err) {
+			t.Errorf("%v.DuplicateKeyError() = %v, want a unique violation of the dialect", dialect, err)
+		}
+	}
+}
+
+func TestApplyFault(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := applyFault(ctx, Fault{Delay: 64 * time.Hour}); err != context.Canceled {
+		t.Errorf("applyFault() error = %v, want %v", err, context.Canceled)
+	}
+
+	if err := applyFault(context.Background(), Fault{}); err != nil {
+		t.Errorf("applyFault() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,62 @@
+package sluggable
+
+import (
+	"fmt"
+	"time"
+)
+
+// FreezeWindow is a period in which stored slugs must not change, e.g. during a sales event.
+type FreezeWindow struct {
+	Start  time.Time
+	End    time.Time
+	Reason string // Optional, e.g. "Black Friday"
+}
+
+func (w FreezeWindow) contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// FrozenError is returned when a slug change falls into a freeze window. It matches ErrSlugsFrozen with
+// errors.Is, use errors.As to tell users until when URL changes are frozen.
+type FrozenError struct {
+	Window FreezeWindow
+}
+
+func (e *FrozenError) Error() string {
+	message := fmt.Sprintf("%v until %s", ErrSlugsFrozen, e.Window.End.Format(time.RFC3339))
+	if e.Window.Reason != "" {
+		message += " (" + e.Window.Reason + ")"
+	}
+
+	return message
+}
+
+func (e *FrozenError) Unwrap() error {
+	return ErrSlugsFrozen
+}
+
+// WithFreezeWindows blocks GenerateAndSave and ApplyDue from changing stored slugs during the windows.
+// Saving the first slug of a record or an unchanged slug isn't affected. Repeated calls add windows.
+func WithFreezeWindows(windows ...FreezeWindow) sluggableOption {
+	return func(opts *options) {
+		opts.freezeWindows = append(opts.freezeWindows[:len(opts.freezeWindows):len(opts.freezeWindows)], windows...)
+	}
+}
+
+// checkFrozen returns a *FrozenError when now falls into a freeze window, for overlapping windows the
+// one ending last.
+func (o options) checkFrozen(now time.Time) error {
+	var frozen *FrozenError
+
+	for _, window := range o.freezeWindows {
+		if window.contains(now) && (frozen == nil || window.End.After(frozen.Window.End)) {
+			frozen = &FrozenError{Window: window}
+		}
+	}
+
+	if frozen == nil {
+		return nil
+	}
+
+	return frozen
+}
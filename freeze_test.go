@@ -0,0 +1,115 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithFreezeWindows(t *testing.T) {
+	now := time.Now()
+
+	sale := FreezeWindow{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Reason: "Black Friday"}
+	longer := FreezeWindow{Start: now.Add(-time.Minute), End: now.Add(48 * time.Hour)}
+	past := FreezeWindow{Start: now.Add(-48 * time.Hour), End: now.Add(-24 * time.Hour)}
+
+	tests := []struct {
+		name    string
+		windows []FreezeWindow
+		wantEnd time.Time
+	}{
+		{name: "no windows"},
+		{name: "past window", windows: []FreezeWindow{past}},
+		{name: "active window", windows: []FreezeWindow{past, sale}, wantEnd: sale.End},
+		{name: "overlapping windows", windows: []FreezeWindow{sale, longer}, wantEnd: longer.End},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(WithFreezeWindows(tt.windows...)).options.checkFrozen(now)
+
+			var frozen *FrozenError
+			if tt.wantEnd.IsZero() {
+				if err != nil {
+					t.Errorf("checkFrozen() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrSlugsFrozen) || !errors.As(err, &frozen) || !frozen.Window.End.Equal(tt.wantEnd) {
+				t.Errorf("checkFrozen() error = %v, want frozen until %v", err, tt.wantEnd)
+			}
+		})
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	s := New(WithTableName("articles"), WithPendingTable("slug_changes"), WithFreezeWindows(sale))
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+	mock.ExpectQuery(`SELECT "slug" FROM "articles"`).WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("goodbye-world"))
+
+	_, err = s.GenerateAndSave(context.Background(), db, "Hello World", WithIdentifier(42))
+	if !errors.Is(err, ErrSlugsFrozen) || !strings.Contains(err.Error(), "Black Friday") {
+		t.Errorf("Sluggable.GenerateAndSave() error = %v, want %v", err, ErrSlugsFrozen)
+	}
+
+	if _, err := s.ApplyDue(context.Background(), db); !errors.Is(err, ErrSlugsFrozen) {
+		t.Errorf("Sluggable.ApplyDue() error = %v, want %v", err, ErrSlugsFrozen)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestFreezeWindowsAllowUnchangedSlugs(t *testing.T) {
+	now := time.Now()
+	sale := FreezeWindow{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}
+
+	tests := []struct {
+		name    string
+		current any
+	}{
+		{name: "first slug", current: nil},
+		{name: "unchanged slug", current: "hello-world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+			mock.ExpectQuery(`SELECT "slug" FROM "articles"`).WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow(tt.current))
+			mock.ExpectExec(`UPDATE "articles" SET "slug"`).WithArgs("hello-world", 42).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			s := New(WithTableName("articles"), WithFreezeWindows(sale))
+
+			got, err := s.GenerateAndSave(context.Background(), db, "Hello World", WithIdentifier(42))
+			if err != nil {
+				t.Fatalf("Sluggable.GenerateAndSave() error = %v", err)
+			}
+
+			if got != "hello-world" {
+				t.Errorf("Sluggable.GenerateAndSave() = %v, want %v", got, "hello-world")
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
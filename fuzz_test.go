@@ -0,0 +1,122 @@
+package sluggable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzMakeSlug(f *testing.F) {
+	for _, seed := range []string{"Hello World", "", "Ünïcödé Straße", "北京", "a\x00b", "..--__", "report.PDF", "\xff\xfe", strings.Repeat("ab ", 1000)} {
+		f.Add(seed)
+	}
+
+	presets := []*Sluggable{
+		New(),
+		New(WithPreset(KubernetesName())),
+		New(WithPreset(FileKey()), WithMaxLength(20), WithTruncateOnWordBoundary()),
+		New(WithPreset(EmailLocalPart()), WithBlockedWords("darn"), WithBlockedWordAction(BlockMask)),
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		for _, s := range presets {
+			opts, slug, err := s.options.baseSlug(value)
+			if err != nil {
+				continue
+			}
+
+			if !utf8.ValidString(slug) {
+				t.Errorf("baseSlug(%q) = %q, want valid UTF-8", value, slug)
+			}
+
+			if strings.ContainsRune(slug, 0) {
+				t.Errorf("baseSlug(%q) = %q, want no NUL", value, slug)
+			}
+
+			if opts.maxLength > 0 && utf8.RuneCountInString(slug)+utf8.RuneCountInString(opts.extension) > opts.maxLength {
+				t.Errorf("baseSlug(%q) = %q, longer than %d", value, slug, opts.maxLength)
+			}
+		}
+	})
+}
+
+func FuzzCanonicalPath(f *testing.F) {
+	for _, seed := range []string{"/", "", "//a///b/", "/Blog/Old-Title", "/a--b", "/\x00", "/\xff"} {
+		f.Add(seed)
+	}
+
+	s := New(WithRedirects(map[string]string{"old-title": "new-title", "a": "b", "b": "a"}))
+
+	f.Fuzz(func(t *testing.T, path string) {
+		canonical, _ := s.CanonicalPath(path)
+
+		if !strings.HasPrefix(canonical, "/") || strings.Contains(canonical, "//") {
+			t.Errorf("CanonicalPath(%q) = %q, want a single leading slash and no empty segments", path, canonical)
+		}
+
+		// Canonical paths are stable
+		if again, changed := s.CanonicalPath(canonical); changed {
+			t.Errorf("CanonicalPath(%q) = %q, want %q unchanged", canonical, again, canonical)
+		}
+	})
+}
+
+func FuzzParseSuffix(f *testing.F) {
+	for _, seed := range []string{"hello-world-2", "hello-world", "hello-world-", "hello-world-99999999999999999999", "hello-world--2"} {
+		f.Add(seed)
+	}
+
+	s := New()
+
+	f.Fuzz(func(t *testing.T, candidate string) {
+		if suffix, ok := s.options.parseSuffix("hello-world", candidate); ok && suffix < 0 {
+			t.Errorf("parseSuffix(%q) = %d, want a positive suffix", candidate, suffix)
+		}
+	})
+}
+
+func FuzzNormalize(f *testing.F) {
+	for _, seed := range []string{"Hello World", "", "a\x00b", "\xff\xfe", "Ünïcödé Straße", strings.Repeat("ab ", 300), strings.Repeat("北京", 200)} {
+		f.Add(seed)
+	}
+
+	s := New(WithMaxInputLength(1024), WithMaxLength(64))
+
+	f.Fuzz(func(t *testing.T, value string) {
+		slug := s.Normalize(value)
+
+		if len(value) > 1024 && slug != "" {
+			t.Errorf("Normalize() of %d bytes = %q, want the value rejected", len(value), slug)
+		}
+
+		if utf8.RuneCountInString(slug) > 64 {
+			t.Errorf("Normalize(%q) = %q, longer than 64", value, slug)
+		}
+
+		if !utf8.ValidString(slug) || strings.ContainsRune(slug, 0) {
+			t.Errorf("Normalize(%q) = %q, want valid UTF-8 without NUL", value, slug)
+		}
+	})
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, seed := range []string{"hello-world", "", "admin", "Hello World", "a\x00b", "\xff", strings.Repeat("a", 64), strings.Repeat("a", 2000)} {
+		f.Add(seed)
+	}
+
+	s := New(WithMaxInputLength(1024), WithMaxLength(64), WithReserved("admin"))
+
+	f.Fuzz(func(t *testing.T, slug string) {
+		err := s.Validate(slug)
+
+		if len(slug) > 1024 && !errors.Is(err, ErrInputTooLong) {
+			t.Errorf("Validate() of %d bytes error = %v, want %v", len(slug), err, ErrInputTooLong)
+		}
+
+		// Valid slugs are their own normalization and fit the max length
+		if err == nil && (s.Normalize(slug) != slug || utf8.RuneCountInString(slug) > 64) {
+			t.Errorf("Validate(%q) = nil, want an error", slug)
+		}
+	})
+}
@@ -1,10 +1,14 @@
 package sluggable
 
 import (
-	slugify "github.com/gosimple/slug"
+	"sync"
 )
 
-// var _global *Sluggable
+//nolint:gochecknoglobals
+var (
+	_global      *Sluggable
+	_globalMutex sync.RWMutex
+)
 
 const (
 	excludeDeletedWhere = `"deleted_at" IS NULL`
@@ -12,27 +16,67 @@ const (
 
 func getDefaultOptions() options {
 	return options{
-		method: func(value, separator string) string {
-			return slugify.MakeLang(value, "en")
-		},
+		method:            rulesV1,
 		separator:         "-",
 		tableName:         "",
 		columnName:        "slug",
+		identifierColumn:  "id",
+		softDeleteColumn:  "deleted_at",
+		tenantColumn:      "tenant_id",
 		firstUniqueSuffix: 2,
-		wheres: map[string][]any{
-			excludeDeletedWhere: {},
+		suffixStrategy:    NumericSuffix,
+		collisionFallback: RandomSuffix(8),
+		conflictRetry:     3,
+		paramStartIndex:   1,
+		maxInputLength:    defaultMaxInputLength,
+		wheres: []whereClause{
+			{SQL: excludeDeletedWhere},
 		},
 	}
 }
 
-// func Configure(options ...sluggableOption) {
-// 	// if _global == nil {
-// 	// 	_global = New(options...)
+// Configure changes the options used by the package-level Generate.
+// Options are applied on top of the current global configuration.
+func Configure(options ...sluggableOption) {
+	_globalMutex.Lock()
+	defer _globalMutex.Unlock()
 
-// 	// 	return
-// 	// }
+	opts := getDefaultOptions()
+	if _global != nil {
+		opts = _global.options
+	}
+
+	for _, option := range options {
+		option(&opts)
+	}
 
-// 	// for _, option := range options {
-// 	// 	option(_global.options)
-// 	// }
-// }
+	// Replace instead of mutating so concurrent Generate calls keep a consistent configuration
+	_global = &Sluggable{options: opts}
+}
+
+// ResetGlobal restores the default global configuration, mostly useful in tests.
+func ResetGlobal() {
+	_globalMutex.Lock()
+	defer _globalMutex.Unlock()
+
+	_global = nil
+}
+
+func getGlobal() *Sluggable {
+	_globalMutex.RLock()
+	global := _global
+	_globalMutex.RUnlock()
+
+	if global != nil {
+		return global
+	}
+
+	_globalMutex.Lock()
+	defer _globalMutex.Unlock()
+
+	if _global == nil {
+		_global = New()
+	}
+
+	return _global
+}
@@ -0,0 +1,215 @@
+package sluggable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// WithParentColumn makes Resolve follow nested slug paths like "docs/getting-started/install" through the
+// column referencing the parent record, e.g. "parent_id". Root records have a NULL parent. Generate the slugs
+// with WithScope(column, parentID), so they're unique among their siblings.
+func WithParentColumn(column string) sluggableOption {
+	return func(opts *options) {
+		opts.parentColumn = column
+	}
+}
+
+// Segment is a level of a nested slug path, e.g. for breadcrumbs.
+type Segment struct {
+	Slug string `json:"slug"`
+	Path string `json:"path"` // Path up to and including the segment, e.g. "/docs/getting-started"
+	ID   string `json:"id"`   // Empty when no record has the path
+}
+
+// Breadcrumbs splits a nested slug path into its segments, with their paths and the identifiers of their
+// records resolved in one query, see WithParentColumn. Segments below an unknown one have no identifier.
+func (s *Sluggable) Breadcrumbs(db contextExecutor, path string, options ...sluggableOption) ([]Segment, error) {
+	return s.BreadcrumbsContext(context.Background(), db, path, options...)
+}
+
+func (s *Sluggable) BreadcrumbsContext(ctx context.Context, db contextExecutor, path string, options ...sluggableOption) ([]Segment, error) {
+	if db == nil {
+		return nil, fmt.Errorf("[sluggable] db cannot be nil when resolving breadcrumbs")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.parentColumn == "" {
+		return nil, fmt.Errorf("[sluggable] breadcrumbs need a parent column, see WithParentColumn")
+	}
+
+	opts.identifier = nil
+
+	slugs := splitPath(path)
+
+	ids, err := resolvePath(ctx, db, opts, slugs)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]Segment, len(slugs))
+	for i, slug := range slugs {
+		segments[i] = Segment{Slug: slug, Path: "/" + strings.Join(slugs[:i+1], "/")}
+
+		if i < len(ids) {
+			segments[i].ID = ids[i]
+		}
+	}
+
+	return segments, nil
+}
+
+// pathNode is a record matched by a segment of a slug path.
+type pathNode struct {
+	id     string
+	parent string
+}
+
+// splitPath returns the non-empty segments of a slug path.
+func splitPath(path string) []string {
+	segments := make([]string, 0, strings.Count(path, "/")+1)
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// resolvePath returns the identifiers of the records along the path, from the root, in one query. Resolving stops
+// at the first segment without a record, so fewer identifiers than segments are returned for unknown paths.
+func resolvePath(ctx context.Context, db contextExecutor, opts options, segments []string) ([]string, error) {
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	query, params, err := buildPathQuery(opts, segments)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", params)
+	}
+
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to query slug path: %w", err)
+	}
+	defer rows.Close()
+
+	levels := make([][]pathNode, len(segments))
+
+	for rows.Next() {
+		var idValue, parentValue any
+
+		var depth int
+		if err := rows.Scan(&idValue, &parentValue, &depth); err != nil {
+			return nil, fmt.Errorf("[sluggable] failed to scan slug path: %w", err)
+		}
+
+		if depth >= 1 && depth <= len(segments) {
+			levels[depth-1] = append(levels[depth-1], pathNode{id: identifierString(idValue), parent: identifierString(parentValue)})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to iterate slug path: %w", err)
+	}
+
+	// Every record of a level was reached through a parent on the level above, so walk up from the deepest one
+	deepest := len(levels)
+	for deepest > 0 && len(levels[deepest-1]) == 0 {
+		deepest--
+	}
+
+	ids := make([]string, deepest)
+	if deepest == 0 {
+		return ids, nil
+	}
+
+	node := levels[deepest-1][0]
+	ids[deepest-1] = node.id
+
+	for depth := deepest - 1; depth > 0; depth-- {
+		for _, parent := range levels[depth-1] {
+			if parent.id == node.parent {
+				node = parent
+
+				break
+			}
+		}
+
+		ids[depth-1] = node.id
+	}
+
+	return ids, nil
+}
+
+// buildPathQuery builds the recursive query matching the first segment among the root records and every
+// following segment among the children of the records matched by the previous one. The where clauses apply
+// on every level.
+func buildPathQuery(opts options, segments []string) (string, []any, error) {
+	if err := opts.validateIdentifiers(); err != nil {
+		return "", nil, err
+	}
+
+	dialect := opts.getDialect()
+	table := opts.qualifiedTable()
+	id, parent := dialect.quote(opts.identifierColumn), dialect.quote(opts.parentColumn)
+	pathID, pathParent, pathDepth := dialect.quote("sluggable_id"), dialect.quote("sluggable_parent"), dialect.quote("sluggable_depth")
+
+	root := fmt.Sprintf(`SELECT %s, %s, 1 FROM %s WHERE (%s = %s) AND (%s IS NULL)`,
+		id, parent, table, opts.slugColumn(), dialect.placeholder(1), parent,
+	)
+
+	conditions, params, err := buildWhereConditions(opts, []any{opts.foldCase(segments[0])}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, condition := range conditions {
+		root += fmt.Sprintf(" AND (%s)", condition)
+	}
+
+	query := root
+
+	if len(segments) > 1 {
+		// The CTE columns are prefixed, so the where clauses keep referring to the table's columns
+		var cases strings.Builder
+
+		for depth, segment := range segments[1:] {
+			params = append(params, opts.foldCase(segment))
+			fmt.Fprintf(&cases, " WHEN %d THEN %s", depth+1, dialect.placeholder(len(params)))
+		}
+
+		children := fmt.Sprintf(`SELECT %s, %s, %s + 1 FROM %s INNER JOIN %s ON %s = %s WHERE (%s = CASE %s%s END)`,
+			id, parent, pathDepth, table, dialect.quote("sluggable_path"), parent, pathID, opts.slugColumn(), pathDepth, cases.String(),
+		)
+
+		conditions, params, err = buildWhereConditions(opts, params, 0)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for _, condition := range conditions {
+			children += fmt.Sprintf(" AND (%s)", condition)
+		}
+
+		query += " UNION ALL " + children
+	}
+
+	query = fmt.Sprintf(`%s %s (%s, %s, %s) AS (%s) SELECT %s, %s, %s FROM %s`,
+		dialect.withRecursive, dialect.quote("sluggable_path"), pathID, pathParent, pathDepth, query,
+		pathID, pathParent, pathDepth, dialect.quote("sluggable_path"),
+	)
+
+	return query, params, nil
+}
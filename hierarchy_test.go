@@ -0,0 +1,137 @@
+package sluggable
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const pathQuery = `WITH RECURSIVE "sluggable_path" ("sluggable_id", "sluggable_parent", "sluggable_depth") AS (` +
+	`SELECT "id", "parent_id", 1 FROM "pages" WHERE ("slug" = $1) AND ("parent_id" IS NULL) AND ("deleted_at" IS NULL) UNION ALL ` +
+	`SELECT "id", "parent_id", "sluggable_depth" + 1 FROM "pages" INNER JOIN "sluggable_path" ON "parent_id" = "sluggable_id" ` +
+	`WHERE ("slug" = CASE "sluggable_depth" WHEN 1 THEN $2 WHEN 2 THEN $3 END) AND ("deleted_at" IS NULL)) ` +
+	`SELECT "sluggable_id", "sluggable_parent", "sluggable_depth" FROM "sluggable_path"`
+
+func TestWithParentColumn_Resolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    [][]any // id, parent, depth
+		want    string
+		wantErr error
+	}{
+		{
+			name: "full path",
+			rows: [][]any{{1, nil, 1}, {5, 1, 2}, {9, 5, 3}},
+			want: "9",
+		},
+		{
+			name: "siblings in other branches",
+			rows: [][]any{{1, nil, 1}, {5, 1, 2}, {6, 1, 2}, {9, 6, 3}},
+			want: "9",
+		},
+		{
+			name:    "missing segment",
+			rows:    [][]any{{1, nil, 1}, {5, 1, 2}},
+			wantErr: ErrSlugNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"sluggable_id", "sluggable_parent", "sluggable_depth"})
+			for _, row := range tt.rows {
+				rows.AddRow(row[0], row[1], row[2])
+			}
+
+			mock.ExpectQuery(pathQuery).
+				WithArgs("docs", "getting-started", "install").
+				WillReturnRows(rows)
+
+			s := New(WithTableName("pages"), WithParentColumn("parent_id"))
+
+			got, err := s.Resolve(db, "/docs/getting-started/install")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sluggable.Resolve() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Resolve() = %v, want %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestSluggable_Breadcrumbs(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(pathQuery).
+		WithArgs("docs", "getting-started", "install").
+		WillReturnRows(sqlmock.NewRows([]string{"sluggable_id", "sluggable_parent", "sluggable_depth"}).
+			AddRow(1, nil, 1).
+			AddRow(5, 1, 2))
+
+	s := New(WithTableName("pages"), WithParentColumn("parent_id"))
+
+	got, err := s.Breadcrumbs(db, "docs/getting-started/install")
+	if err != nil {
+		t.Fatalf("Sluggable.Breadcrumbs() error = %v", err)
+	}
+
+	want := []Segment{
+		{Slug: "docs", Path: "/docs", ID: "1"},
+		{Slug: "getting-started", Path: "/docs/getting-started", ID: "5"},
+		{Slug: "install", Path: "/docs/getting-started/install"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sluggable.Breadcrumbs() = %v, want %v", got, want)
+	}
+
+	if _, err := New(WithTableName("pages")).Breadcrumbs(db, "docs"); err == nil {
+		t.Errorf("Sluggable.Breadcrumbs() without parent column error = nil, want error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestBuildPathQuery(t *testing.T) {
+	opts := New(WithTableName("pages"), WithParentColumn("parent_id"), WithDialect(SQLServer)).options
+
+	got, params, err := buildPathQuery(opts, []string{"docs"})
+	if err != nil {
+		t.Fatalf("buildPathQuery() error = %v", err)
+	}
+
+	want := `WITH [sluggable_path] ([sluggable_id], [sluggable_parent], [sluggable_depth]) AS (` +
+		`SELECT [id], [parent_id], 1 FROM [pages] WHERE ([slug] = @p1) AND ([parent_id] IS NULL) AND ([deleted_at] IS NULL)) ` +
+		`SELECT [sluggable_id], [sluggable_parent], [sluggable_depth] FROM [sluggable_path]`
+	if got != want {
+		t.Errorf("buildPathQuery() = %v, want %v", got, want)
+	}
+
+	if len(params) != 1 || params[0] != "docs" {
+		t.Errorf("buildPathQuery() params = %v, want %v", params, []any{"docs"})
+	}
+
+	opts.parentColumn = "parent_id; DROP TABLE pages"
+	if _, _, err := buildPathQuery(opts, []string{"docs"}); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("buildPathQuery() error = %v, want %v", err, ErrInvalidIdentifier)
+	}
+}
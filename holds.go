@@ -0,0 +1,76 @@
+package sluggable
+
+import (
+	"sync"
+	"time"
+)
+
+// Holds reserves generated slugs in-process for a short time, until the caller confirms the slug was
+// stored or the TTL expires. Other records don't receive a held slug, while retries for the same
+// identifier (e.g. a double submitted form) get the same slug again. Generations without an identifier
+// can't be told apart, so they never receive a held slug.
+type Holds struct {
+	mutex sync.Mutex
+	ttl   time.Duration
+	now   func() time.Time
+	held  map[string]slugHold
+}
+
+type slugHold struct {
+	identifier string
+	expires    time.Time
+}
+
+func NewHolds(ttl time.Duration) *Holds {
+	return &Holds{ttl: ttl, now: time.Now, held: make(map[string]slugHold)}
+}
+
+// WithHolds makes generated slugs unavailable to other identifiers until confirmed or expired.
+func WithHolds(holds *Holds) sluggableOption {
+	return func(opts *options) {
+		opts.holds = holds
+	}
+}
+
+// Confirm releases the hold once the slug is stored, from then on the database reports it as taken.
+func (h *Holds) Confirm(slug string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(h.held, slug)
+}
+
+// isHeld reports whether the slug is held for another identifier, or at all when the identifier is empty.
+// Expired holds are removed.
+func (h *Holds) isHeld(slug, identifier string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	hold, ok := h.held[slug]
+	if !ok {
+		return false
+	}
+
+	if !h.now().Before(hold.expires) {
+		delete(h.held, slug)
+
+		return false
+	}
+
+	return identifier == "" || hold.identifier != identifier
+}
+
+// hold holds the slug for the identifier and removes expired holds, so unconfirmed slugs don't pile up.
+func (h *Holds) hold(slug, identifier string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	now := h.now()
+	for held, hold := range h.held {
+		if !now.Before(hold.expires) {
+			delete(h.held, held)
+		}
+	}
+
+	h.held[slug] = slugHold{identifier: identifier, expires: now.Add(h.ttl)}
+}
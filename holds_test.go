@@ -0,0 +1,82 @@
+package sluggable
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithHolds(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	holds := NewHolds(time.Minute)
+	holds.now = func() time.Time { return now }
+
+	available := func(context.Context, string) (bool, error) { return true, nil }
+	s := New(WithHolds(holds), WithAvailabilityChecker(available))
+
+	generate := func(identifier any) string {
+		t.Helper()
+
+		got, err := s.Generate(nil, "Hello World", WithIdentifier(identifier))
+		if err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+
+		return got
+	}
+
+	if got := generate("1"); got != "hello-world" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world")
+	}
+
+	// A retry for the same record gets the held slug again, another record doesn't
+	if got := generate("1"); got != "hello-world" {
+		t.Errorf("Sluggable.Generate() retry = %v, want %v", got, "hello-world")
+	}
+
+	if got := generate("2"); got != "hello-world-2" {
+		t.Errorf("Sluggable.Generate() for another record = %v, want %v", got, "hello-world-2")
+	}
+
+	holds.Confirm("hello-world")
+
+	if got := generate("3"); got != "hello-world" {
+		t.Errorf("Sluggable.Generate() after confirm = %v, want %v", got, "hello-world")
+	}
+
+	now = now.Add(time.Minute)
+
+	if got := generate("4"); got != "hello-world" {
+		t.Errorf("Sluggable.Generate() after expiry = %v, want %v", got, "hello-world")
+	}
+}
+
+func TestHoldsWithoutIdentifier(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	holds := NewHolds(time.Minute)
+	holds.now = func() time.Time { return now }
+
+	available := func(context.Context, string) (bool, error) { return true, nil }
+	s := New(WithHolds(holds), WithAvailabilityChecker(available))
+
+	// Two new records racing for the same title
+	for _, want := range []string{"hello-world", "hello-world-2"} {
+		got, err := s.Generate(nil, "Hello World")
+		if err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+
+		if got != want {
+			t.Errorf("Sluggable.Generate() = %v, want %v", got, want)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	holds.hold("goodbye-world", "1")
+
+	if len(holds.held) != 1 {
+		t.Errorf("holds after expiry = %d, want %d", len(holds.held), 1)
+	}
+}
@@ -0,0 +1,53 @@
+package sluggable
+
+import (
+	"strings"
+)
+
+const defaultLang = "en"
+
+// langMethods maps the built-in slug methods to their variant taking the WithLang language, keyed by
+// function name like CompareOptions. Custom methods set with WithMethod don't know about languages.
+//
+//nolint:gochecknoglobals
+var langMethods = map[string]func(value, separator, lang string) string{
+	methodName(rulesV1):              rulesV1Lang,
+	methodName(rfc1123Method):        rfc1123LangMethod,
+	methodName(emailLocalPartMethod): emailLocalPartLangMethod,
+	methodName(handleMethod):         handleLangMethod,
+}
+
+// WithLang transliterates with the substitutions of the given language, e.g. "de" turns "ü" into "ue"
+// and "&" into "und". Accepts the ISO 639 codes of github.com/gosimple/slug, unknown languages fall back
+// to English. Only applies to the built-in slug methods, pass it per call for multilingual content.
+func WithLang(lang string) sluggableOption {
+	return func(opts *options) {
+		opts.lang = strings.ToLower(lang)
+	}
+}
+
+// Transliterator turns text of another script into Latin text before the slug method runs. The default
+// method already romanizes CJK characters character by character (北京 → "bei-jing", Japanese kanji get
+// their Chinese reading), a transliterator can use a dictionary instead, e.g. for Japanese readings or
+// Hepburn romanization. Transliterators with large dependencies belong in their own module.
+type Transliterator func(value string) string
+
+// WithTransliterator runs the transliterator on the value before slugifying, after WithSubstitutions.
+func WithTransliterator(transliterator Transliterator) sluggableOption {
+	return func(opts *options) {
+		opts.transliterator = transliterator
+	}
+}
+
+// applyMethod slugifies the value with the slug method, passing the language to built-in methods.
+func (o options) applyMethod(value string) string {
+	if o.lang == "" || o.lang == defaultLang {
+		return o.method(value, o.separator)
+	}
+
+	if method, ok := langMethods[methodName(o.method)]; ok {
+		return method(value, o.separator, o.lang)
+	}
+
+	return o.method(value, o.separator)
+}
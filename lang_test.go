@@ -0,0 +1,85 @@
+package sluggable
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWithLang(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    string
+	}{
+		{name: "english by default", value: "Über Müller & Söhne", want: "uber-muller-and-sohne"},
+		{name: "german", options: []sluggableOption{WithLang("de")}, value: "Über Müller & Söhne", want: "ueber-mueller-und-soehne"},
+		{name: "case insensitive code", options: []sluggableOption{WithLang("DE")}, value: "Müller", want: "mueller"},
+		{name: "unknown falls back to english", options: []sluggableOption{WithLang("xx")}, value: "Tom & Jerry", want: "tom-and-jerry"},
+		{
+			name:    "built-in preset methods",
+			options: []sluggableOption{WithPreset(EmailLocalPart()), WithLang("de")},
+			value:   "Jürgen Müller",
+			want:    "juergen.mueller",
+		},
+		{
+			name:    "custom methods ignore the language",
+			options: []sluggableOption{WithMethod(func(value, _ string) string { return value }), WithLang("de")},
+			value:   "Müller",
+			want:    "Müller",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := New(tt.options...).options.makeSlug(tt.value); got != tt.want {
+				t.Errorf("makeSlug(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	// Per call, the instance language is replaced
+	available := func(context.Context, string) (bool, error) { return true, nil }
+	s := New(WithLang("de"), WithAvailabilityChecker(available))
+
+	got, err := s.Generate(nil, "Müller", WithLang("en"))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "muller" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "muller")
+	}
+}
+
+func TestWithTransliterator(t *testing.T) {
+	readings := strings.NewReplacer("東京", " Tokyo ", "タワー", " Tower ")
+
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    string
+	}{
+		{name: "romanized by default", value: "東京タワー", want: "dong-jing-tawa"},
+		{name: "transliterator", options: []sluggableOption{WithTransliterator(readings.Replace)}, value: "東京タワー", want: "tokyo-tower"},
+		{
+			name: "after substitutions",
+			options: []sluggableOption{
+				WithSubstitutions(map[string]string{"&": "and"}),
+				WithTransliterator(func(value string) string { return strings.ReplaceAll(value, "and", "und") }),
+			},
+			value: "Tom & Jerry",
+			want:  "tom-und-jerry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := New(tt.options...).options.makeSlug(tt.value); got != tt.want {
+				t.Errorf("makeSlug(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,23 +1,213 @@
 package sluggable
 
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
 type options struct {
-	debug bool // Defaults to false
+	debug       bool                                         // Defaults to false
+	decisionLog func(ctx context.Context, decision Decision) // Optional, receives every generation decision
+	trace       *decisionTrace                               // Set per call when decisions are logged
+
+	presets []string // Names of the applied presets
+
+	method       func(value, separator string) string // Defaults to "slugify"
+	rulesVersion int                                  // Optional, 0 when the method isn't pinned to a rule set
+	separator    string                               // Defaults to "-"
+	lang         string                               // Optional, defaults to "en" for the built-in methods
+
+	suffixSeparator string // Optional, joins the uniqueness suffix, defaults to the separator
+
+	substitutions   map[string]string // Optional, applied before slugifying
+	transliterator  Transliterator    // Optional, applied after the substitutions
+	foldConfusables bool              // Defaults to false
+	untitled        string            // Optional, base used when the value produces an empty slug
+
+	blockedWords      [][]string        // Optional, lowercased words of every blocked term
+	blockedWordAction BlockedWordAction // Defaults to BlockReject
+
+	forbidNumericOnly bool   // Defaults to false
+	numericPrefix     string // Optional, added to numeric-only slugs
+	numericSuffix     string // Optional, added to numeric-only slugs
 
-	method    func(value, separator string) string // Defaults to "slugify"
-	separator string                               // Defaults to "-"
+	minLength     int           // Defaults to 0
+	emptyFallback EmptyFallback // Defaults to FallbackNone, empty slugs are kept
 
+	maxLength       int  // Defaults to 0, no limit
+	truncateOnWords bool // Defaults to false
+	maxInputLength  int  // Defaults to 64 KiB, longer values are rejected before slugifying, 0 for no limit
+
+	constraint *regexp.Regexp // Optional, the final slug must match it
+
+	preserveExtension bool   // Defaults to false
+	extension         string // Set per call from the value when preserveExtension is enabled
+
+	value     string // Set per call, the value before slugifying
+	slugified bool   // Set per call when the value is already slugified, e.g. by GenerateFrom
+	truncated bool   // Set per call when the base slug was shortened to the max length
+	deferHold bool   // Set per call when the caller holds the final slug itself, e.g. GenerateShort
+
+	schema     string // Optional, qualifies the table name
 	tableName  string // Empty by default, must be set
 	columnName string // Defaults to "slug"
 
-	identifier string // Optional, used to check for existing slugs
+	sourceColumn string // Optional, column the slugs are generated from, used by Simulate
+	pendingTable string // Optional, table of the scheduled slug changes, used by ScheduleChange and ApplyDue
+
+	identifier       any    // Optional, used to check for existing slugs
+	identifierColumn string // Defaults to "id"
+	parentColumn     string // Optional, e.g. "parent_id", makes Resolve follow slug paths
+
+	rowDecoder   func(rows *sql.Rows) (id, slug string, err error) // Optional, decodes the similar slug rows
+	queryBuilder func(q QueryParts) (sql string, args []any)       // Optional, replaces the similar slugs query
+
+	maxCandidateRows int // Defaults to 0, no limit
 
-	firstUniqueSuffix int // Defaults to 2
+	caseInsensitive bool // Defaults to false
 
-	wheres map[string][]any // Optional, used to add additional where clauses
+	firstUniqueSuffix int            // Defaults to 2
+	suffixStrategy    SuffixStrategy // Defaults to NumericSuffix
+	suffixFormat      string         // Optional, e.g. "%03d" for numeric suffixes
+
+	maxCollisionSuffix int                                      // Defaults to 0, no limit
+	collisionFallback  SuffixStrategy                           // Defaults to RandomSuffix(8)
+	suffixWarningLimit int                                      // Optional, the highest suffix without a warning
+	suffixWarning      func(ctx context.Context, result Result) // Optional, called past suffixWarningLimit
+
+	reservedTables      []reservedTable                                                    // Optional, e.g. a redirects table
+	reserved            map[string]struct{}                                                // Optional, slugs that are never handed out
+	availabilityChecker func(ctx context.Context, slug string) (available bool, err error) // Optional, e.g. a DNS lookup
+	holds               *Holds                                                             // Optional, slugs handed out but not stored yet
+
+	wheres          []whereClause // Optional, used to add additional where clauses
+	scopes          []scope       // Optional, slugs are only unique among rows with the same scope values
+	paramStartIndex int           // Defaults to 1, first placeholder number of SimilarWhere
+
+	softDeleteColumn  string // Defaults to "deleted_at", excluded unless WithDeleted is used
+	softDeleteBoolean bool   // Defaults to false, the column is NULL for live rows
+	softDeleteLive    bool   // Value of the boolean column for live rows
+
+	tenantColumn   string                        // Defaults to "tenant_id"
+	tenantResolver func(ctx context.Context) any // Optional, scopes every call to the tenant of its context
+
+	dialect Dialect  // Detected from the driver when empty, falls back to Postgres
+	lock    LockMode // Defaults to NoLock
+
+	advisoryLock     bool          // Defaults to false
+	statementTimeout time.Duration // Defaults to 0, no timeout
+	preview          bool          // Defaults to false, skips holds and locks
+	readOnly         bool          // Defaults to false, rejects writes and skips holds and locks
+	driverName       string        // Optional, used to detect the dialect
+
+	conflictRetry int // Defaults to 3, attempts made by GenerateWith
+
+	approval      func(old, new string) (approved bool, err error) // Optional, gates GenerateAndSave changing a stored slug
+	freezeWindows []FreezeWindow                                   // Optional, periods without slug changes
+
+	redirects map[string]string // Optional, historical slug → current slug, used by CanonicalPath
+
+	faultInjector FaultInjector // Optional, only used in sluggable_chaos builds
 }
 
 type sluggableOption func(*options)
 
+// EmptyFallback decides what happens when a value produces an empty or too short slug,
+// e.g. emoji-only titles.
+type EmptyFallback int
+
+const (
+	FallbackNone  EmptyFallback = iota // Keep the slug, even when empty
+	FallbackUUID                       // Use a random UUID
+	FallbackHash                       // Use the first 12 hex characters of a SHA-256 of the value
+	FallbackError                      // Fail with ErrSlugTooShort
+)
+
+// validate checks the options for combinations that would produce broken queries or slugs.
+// The table name may still be empty since it's usually given per call.
+func (o options) validate() error {
+	if err := validateRulesVersion(o.rulesVersion); err != nil {
+		return err
+	}
+
+	if o.method == nil {
+		return fmt.Errorf("[sluggable] method cannot be nil")
+	}
+
+	if o.separator == "" {
+		return fmt.Errorf("[sluggable] separator cannot be empty")
+	}
+
+	if o.firstUniqueSuffix < 0 {
+		return fmt.Errorf("[sluggable] first unique suffix cannot be negative, got %d", o.firstUniqueSuffix)
+	}
+
+	if err := validateSuffixFormat(o.suffixFormat); err != nil {
+		return err
+	}
+
+	if err := o.suffixStrategy.validate(); err != nil {
+		return err
+	}
+
+	if err := o.collisionFallback.validate(); err != nil {
+		return err
+	}
+
+	if o.paramStartIndex < 1 {
+		return fmt.Errorf("[sluggable] param start index must be at least 1, got %d", o.paramStartIndex)
+	}
+
+	if o.conflictRetry < 1 {
+		return fmt.Errorf("[sluggable] conflict retry must be at least 1, got %d", o.conflictRetry)
+	}
+
+	if err := o.validateIdentifiers(); err != nil {
+		return err
+	}
+
+	for _, where := range o.wheres {
+		if _, err := bindPlaceholders(o.getDialect(), where.SQL, where.Args, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type whereClause struct {
+	SQL  string
+	Args []any
+
+	column string                       // Set for the structured clauses, validated like the other identifiers
+	render func(dialect Dialect) string // Optional, renders SQL with the quoting of the dialect
+}
+
+type scope struct {
+	column string
+	value  any
+}
+
+func (o options) getSuffixSeparator() string {
+	if o.suffixSeparator == "" {
+		return o.separator
+	}
+
+	return o.suffixSeparator
+}
+
+func (o options) getDialect() Dialect {
+	if o.dialect.name == "" {
+		return Postgres
+	}
+
+	return o.dialect
+}
+
 func WithDebug(debug bool) sluggableOption {
 	return func(opts *options) {
 		opts.debug = debug
@@ -27,6 +217,82 @@ func WithDebug(debug bool) sluggableOption {
 func WithMethod(method func(value, separator string) string) sluggableOption {
 	return func(opts *options) {
 		opts.method = method
+		opts.rulesVersion = 0
+	}
+}
+
+func WithConfusableFolding() sluggableOption {
+	return func(opts *options) {
+		opts.foldConfusables = true
+	}
+}
+
+func WithUntitled(untitled string) sluggableOption {
+	return func(opts *options) {
+		opts.untitled = untitled
+	}
+}
+
+// WithMaxLength limits slugs to maxLength characters, suffix and extension included. The base slug is
+// truncated to make room for the suffix, e.g. for VARCHAR(255) columns or SEO limits.
+func WithMaxLength(maxLength int) sluggableOption {
+	return func(opts *options) {
+		opts.maxLength = maxLength
+	}
+}
+
+// WithMaxInputLength rejects values longer than maxInputLength bytes with ErrInputTooLong before they are
+// slugified, which bounds the memory spent on untrusted input. Defaults to 64 KiB, 0 disables the limit.
+func WithMaxInputLength(maxInputLength int) sluggableOption {
+	return func(opts *options) {
+		opts.maxInputLength = maxInputLength
+	}
+}
+
+// WithTruncateOnWordBoundary makes WithMaxLength drop whole words instead of cutting a word in half.
+func WithTruncateOnWordBoundary() sluggableOption {
+	return func(opts *options) {
+		opts.truncateOnWords = true
+	}
+}
+
+// WithConstraint validates the final slug, suffix and extension included, against the pattern and fails
+// with ErrConstraintViolation when it doesn't match, e.g. for the slug grammar of a CDN or a legacy router.
+// Anchor the pattern with ^ and $ to match the whole slug.
+func WithConstraint(pattern *regexp.Regexp) sluggableOption {
+	return func(opts *options) {
+		opts.constraint = pattern
+	}
+}
+
+// WithForbidNumericOnly keeps slugs from looking like ids, e.g. "2024" would shadow /articles/{id} routes.
+// Numeric-only slugs get the slugified prefix and suffix joined with the separator ("Year " gives
+// "year-2024"), or fail with ErrNumericOnlySlug when both slugify to nothing.
+func WithForbidNumericOnly(prefix, suffix string) sluggableOption {
+	return func(opts *options) {
+		opts.forbidNumericOnly = true
+		opts.numericPrefix = prefix
+		opts.numericSuffix = suffix
+	}
+}
+
+// WithMinLength treats slugs shorter than minLength like empty slugs, see WithEmptyFallback.
+func WithMinLength(minLength int) sluggableOption {
+	return func(opts *options) {
+		opts.minLength = minLength
+	}
+}
+
+// WithEmptyFallback sets what replaces an empty or too short slug. WithUntitled is tried first.
+func WithEmptyFallback(fallback EmptyFallback) sluggableOption {
+	return func(opts *options) {
+		opts.emptyFallback = fallback
+	}
+}
+
+func WithPreserveExtension() sluggableOption {
+	return func(opts *options) {
+		opts.preserveExtension = true
 	}
 }
 
@@ -36,38 +302,284 @@ func WithSeparator(separator string) sluggableOption {
 	}
 }
 
+// WithSuffixSeparator joins the uniqueness suffix with a different separator than the words,
+// e.g. "hello_world-2" with WithSeparator("_").
+func WithSuffixSeparator(separator string) sluggableOption {
+	return func(opts *options) {
+		opts.suffixSeparator = separator
+	}
+}
+
+// WithSuffixFormat formats numeric suffixes with fmt, e.g. "%03d" renders "hello-world-002" so slugs sort
+// in the same order as their suffixes.
+func WithSuffixFormat(format string) sluggableOption {
+	return func(opts *options) {
+		opts.suffixFormat = format
+	}
+}
+
 func WithTableName(tableName string) sluggableOption {
 	return func(opts *options) {
 		opts.tableName = tableName
 	}
 }
 
+func WithSchema(schema string) sluggableOption {
+	return func(opts *options) {
+		opts.schema = schema
+	}
+}
+
 func WithColumnName(columnName string) sluggableOption {
 	return func(opts *options) {
 		opts.columnName = columnName
 	}
 }
 
+// WithSourceColumn sets the column the slugs are generated from, e.g. "title".
+func WithSourceColumn(sourceColumn string) sluggableOption {
+	return func(opts *options) {
+		opts.sourceColumn = sourceColumn
+	}
+}
+
+// WithCaseInsensitive compares LOWER(column) against the lowered slug, so "Hello-World" and "hello-world"
+// can't coexist when the column is case-sensitive.
+func WithCaseInsensitive() sluggableOption {
+	return func(opts *options) {
+		opts.caseInsensitive = true
+	}
+}
+
 func WithFirstUniqueSuffix(suffix int) sluggableOption {
 	return func(opts *options) {
 		opts.firstUniqueSuffix = suffix
 	}
 }
 
-func WithIdentifier(identifier string) sluggableOption {
+// WithIdentifier accepts any key type (string, int64, UUID, ...), it's compared to the scanned identifier
+// column after both sides are normalized to a string.
+func WithIdentifier(identifier any) sluggableOption {
 	return func(opts *options) {
 		opts.identifier = identifier
 	}
 }
 
+func WithIdentifierColumn(identifierColumn string) sluggableOption {
+	return func(opts *options) {
+		opts.identifierColumn = identifierColumn
+	}
+}
+
+// WithRowDecoder replaces how the rows of the similar slugs query are decoded, e.g. for composite ids or
+// slugs stored in JSON. The rows contain the identifier column and the slug column, in that order.
+// Rows decoded with an empty slug are ignored.
+func WithRowDecoder(decoder func(rows *sql.Rows) (id, slug string, err error)) sluggableOption {
+	return func(opts *options) {
+		opts.rowDecoder = decoder
+	}
+}
+
+// WithMaxCandidateRows aborts with ErrTooManyCandidates when more than n rows share the base slug. The query
+// is limited to n+1 rows (LIMIT, or TOP on SQL Server), so an unbounded result set is never transferred.
+// Use a random or hash suffix strategy for such bases.
+func WithMaxCandidateRows(n int) sluggableOption {
+	return func(opts *options) {
+		opts.maxCandidateRows = n
+	}
+}
+
+// WithReserved keeps the slugs free for application routes like "admin", "api" or "new". A reserved base
+// slug is suffixed exactly like a taken one ("admin-2"). Repeated calls add slugs. With WithCaseInsensitive
+// reserved slugs are compared case-insensitively as well.
+func WithReserved(slugs ...string) sluggableOption {
+	return func(opts *options) {
+		// Copy so options sharing the same map aren't changed
+		reserved := make(map[string]struct{}, len(opts.reserved)+len(slugs))
+		for slug := range opts.reserved {
+			reserved[slug] = struct{}{}
+		}
+
+		for _, slug := range slugs {
+			reserved[slug] = struct{}{}
+		}
+
+		opts.reserved = reserved
+	}
+}
+
+type reservedTable struct {
+	table  string
+	column string
+}
+
+// WithReservedFromTable treats the slugs in the column of another table as taken, e.g. the paths of a
+// redirects or aliases table, so new slugs never shadow historical URLs. The column has to hold bare
+// slugs, the where clauses of the sluggable table don't apply. Repeated calls add tables.
+func WithReservedFromTable(table, column string) sluggableOption {
+	return func(opts *options) {
+		opts.reservedTables = append(opts.reservedTables[:len(opts.reservedTables):len(opts.reservedTables)], reservedTable{table: table, column: column})
+	}
+}
+
+func WithAvailabilityChecker(checker func(ctx context.Context, slug string) (available bool, err error)) sluggableOption {
+	return func(opts *options) {
+		opts.availabilityChecker = checker
+	}
+}
+
+// WithSoftDeleteColumn excludes rows whose column isn't NULL instead of "deleted_at".
+func WithSoftDeleteColumn(column string) sluggableOption {
+	return func(opts *options) {
+		opts.softDeleteColumn = column
+		opts.softDeleteBoolean = false
+	}
+}
+
+// WithSoftDeleteBoolean excludes soft deleted rows by a boolean flag, e.g. WithSoftDeleteBoolean("is_deleted", false)
+// only considers rows where "is_deleted" is false.
+func WithSoftDeleteBoolean(column string, live bool) sluggableOption {
+	return func(opts *options) {
+		opts.softDeleteColumn = column
+		opts.softDeleteBoolean = true
+		opts.softDeleteLive = live
+	}
+}
+
+// WithDeleted includes soft deleted rows, whichever soft delete column is configured.
 func WithDeleted() sluggableOption {
 	return func(opts *options) {
-		delete(opts.wheres, excludeDeletedWhere)
+		wheres := make([]whereClause, 0, len(opts.wheres))
+
+		for _, where := range opts.wheres {
+			if where.SQL != excludeDeletedWhere {
+				wheres = append(wheres, where)
+			}
+		}
+
+		opts.wheres = wheres
 	}
 }
 
+// WithWhere only considers rows matching the clause. Every "?" outside of quotes is bound to the next param,
+// write "??" for a literal "?", e.g. the Postgres JSONB operator in `"tags" ?? ?`.
 func WithWhere(sql string, params ...any) sluggableOption {
 	return func(opts *options) {
-		opts.wheres[sql] = params
+		// Cap the slice so appending never writes into an array shared with another copy of the options
+		opts.wheres = append(opts.wheres[:len(opts.wheres):len(opts.wheres)], whereClause{SQL: sql, Args: params})
+	}
+}
+
+// WithWhereIn only considers rows whose column has one of the values, e.g. WithWhereIn("status", "published", "draft").
+// Without values no row matches.
+func WithWhereIn(column string, values ...any) sluggableOption {
+	render := func(dialect Dialect) string {
+		if len(values) == 0 {
+			return "1 = 0"
+		}
+
+		return fmt.Sprintf("%s IN (%s)", dialect.quote(column), strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "))
+	}
+
+	return withStructuredWhere(column, render, values)
+}
+
+// WithWhereNull only considers rows whose column is NULL.
+func WithWhereNull(column string) sluggableOption {
+	return withStructuredWhere(column, func(dialect Dialect) string {
+		return dialect.quote(column) + " IS NULL"
+	}, nil)
+}
+
+// WithWhereNotNull only considers rows whose column isn't NULL, e.g. WithWhereNotNull("published_at").
+func WithWhereNotNull(column string) sluggableOption {
+	return withStructuredWhere(column, func(dialect Dialect) string {
+		return dialect.quote(column) + " IS NOT NULL"
+	}, nil)
+}
+
+// withStructuredWhere adds a clause whose column is quoted for the dialect when the query is built.
+func withStructuredWhere(column string, render func(dialect Dialect) string, args []any) sluggableOption {
+	return func(opts *options) {
+		where := whereClause{SQL: render(Postgres), Args: args, column: column, render: render}
+		opts.wheres = append(opts.wheres[:len(opts.wheres):len(opts.wheres)], where)
+	}
+}
+
+// WithScope makes slugs unique per value of the column, e.g. WithScope("user_id", user.ID) for slugs unique
+// per owner. Repeat it for several columns, a nil value matches NULL. Scoping the same column again replaces
+// its value.
+func WithScope(column string, value any) sluggableOption {
+	return func(opts *options) {
+		// Copy so options sharing the same slice aren't changed
+		scopes := make([]scope, 0, len(opts.scopes)+1)
+		for _, existing := range opts.scopes {
+			if existing.column != column {
+				scopes = append(scopes, existing)
+			}
+		}
+
+		opts.scopes = append(scopes, scope{column: column, value: value})
+	}
+}
+
+// WithParamStartIndex numbers the placeholders of SimilarWhere from index, e.g. 3 to follow two
+// placeholders of the surrounding query. Only affects numbered placeholders ($3, @p3).
+func WithParamStartIndex(index int) sluggableOption {
+	return func(opts *options) {
+		opts.paramStartIndex = index
+	}
+}
+
+func WithDialect(dialect Dialect) sluggableOption {
+	return func(opts *options) {
+		opts.dialect = dialect
+	}
+}
+
+func WithDriverName(driverName string) sluggableOption {
+	return func(opts *options) {
+		opts.driverName = driverName
+	}
+}
+
+func WithLock(lock LockMode) sluggableOption {
+	return func(opts *options) {
+		opts.lock = lock
+	}
+}
+
+// WithAdvisoryLock takes pg_advisory_xact_lock(hashtext(base slug)) before looking up similar slugs.
+// The lock is released when the transaction ends, so generate inside a transaction.
+func WithAdvisoryLock() sluggableOption {
+	return func(opts *options) {
+		opts.advisoryLock = true
+	}
+}
+
+// WithStatementTimeout runs SET LOCAL statement_timeout before looking up similar slugs on PostgreSQL, so a
+// runaway scan is cancelled by the server even without a context deadline. The timeout covers the advisory
+// lock and the lookups, the previous timeout is restored afterwards. A failed lookup aborts the transaction
+// and leaves the timeout set until it's rolled back. SET LOCAL has no effect outside a transaction, so
+// generate inside one. Previews don't set a timeout.
+func WithStatementTimeout(timeout time.Duration) sluggableOption {
+	return func(opts *options) {
+		opts.statementTimeout = timeout
+	}
+}
+
+// WithApproval asks approve before GenerateAndSave replaces a stored slug, e.g. for editorial or SEO review
+// of live URLs. Rejected changes fail with ErrChangeNotApproved and nothing is saved, return an error to
+// defer the decision.
+func WithApproval(approve func(old, new string) (approved bool, err error)) sluggableOption {
+	return func(opts *options) {
+		opts.approval = approve
+	}
+}
+
+// WithConflictRetry sets how many slugs GenerateWith tries before giving up on unique constraint violations.
+func WithConflictRetry(maxAttempts int) sluggableOption {
+	return func(opts *options) {
+		opts.conflictRetry = maxAttempts
 	}
 }
@@ -0,0 +1,133 @@
+package sluggable
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// slugAlphabet is the character set and word separator of a built-in slug method.
+type slugAlphabet struct {
+	characters string                 // Regular expression character class content, e.g. "a-z0-9"
+	separator  func(o options) string // Separator between words
+}
+
+// methodAlphabets describes the output of the built-in slug methods, keyed by function name like
+// CompareOptions. Slugs of custom methods can't be described.
+//
+//nolint:gochecknoglobals
+var methodAlphabets = map[string]slugAlphabet{
+	// gosimple/slug always joins words with "-" and keeps underscores
+	methodName(rulesV1):              {characters: "a-z0-9_", separator: func(options) string { return "-" }},
+	methodName(rfc1123Method):        {characters: "a-z0-9", separator: func(options) string { return "-" }},
+	methodName(emailLocalPartMethod): {characters: "a-z0-9", separator: func(o options) string { return o.separator }},
+}
+
+// suffixAlphabets are the characters of the suffixes of the built-in suffix strategies, by strategy name.
+//
+//nolint:gochecknoglobals
+var suffixAlphabets = map[string]string{
+	"numeric": "0-9",
+	"ulid":    "0-9a-z",
+	"random":  "0-9A-Za-z",
+	"hash":    "0-9a-f",
+}
+
+// Pattern returns a regular expression matching every slug the options generate from a value with at
+// least one word, e.g. to keep the pattern of an API spec in sync with the slugs. Fails for custom slug methods and suffix functions,
+// whose output is unknown. The max length isn't part of the pattern, see JSONSchema.
+func (s *Sluggable) Pattern() (string, error) {
+	o := s.options
+
+	alphabet, ok := methodAlphabets[methodName(o.method)]
+	if !ok {
+		return "", fmt.Errorf("[sluggable] no pattern for the custom slug method %s", methodName(o.method))
+	}
+
+	characters := []string{alphabet.characters}
+	separators := []string{alphabet.separator(o), o.getSuffixSeparator()}
+
+	strategies := []SuffixStrategy{o.suffixStrategy}
+	if o.suffixStrategy.suffix == nil && o.maxCollisionSuffix > 0 && o.collisionFallback.suffix != nil {
+		strategies = append(strategies, o.collisionFallback)
+	}
+
+	for _, strategy := range strategies {
+		suffixCharacters, ok := suffixAlphabets[strategy.name]
+		if !ok {
+			return "", fmt.Errorf("[sluggable] no pattern for the %s suffix strategy", strategy)
+		}
+
+		characters = append(characters, suffixCharacters)
+	}
+
+	switch o.emptyFallback {
+	case FallbackUUID:
+		characters = append(characters, "0-9a-f")
+		separators = append(separators, "-")
+	case FallbackHash:
+		characters = append(characters, "0-9a-f")
+	}
+
+	class := ""
+	for _, set := range characters {
+		if !strings.Contains(class, set) {
+			class += set
+		}
+	}
+
+	class = "[" + class + "]+"
+
+	pattern := fmt.Sprintf("^%s(?:%s%s)*", class, alternation(separators), class)
+	if o.preserveExtension {
+		pattern += `(?:\.[a-z0-9]{1,16})?`
+	}
+
+	return pattern + "$", nil
+}
+
+// JSONSchema returns a JSON Schema (and OpenAPI) string schema for the slugs, with the Pattern and
+// the length limits of the options.
+func (s *Sluggable) JSONSchema() (map[string]any, error) {
+	pattern, err := s.Pattern()
+	if err != nil {
+		return nil, err
+	}
+
+	schema := map[string]any{
+		"type":      "string",
+		"pattern":   pattern,
+		"minLength": 1,
+	}
+
+	// Shorter slugs are only ruled out when they fail instead of falling back
+	if s.options.emptyFallback == FallbackError && s.options.minLength > 1 {
+		schema["minLength"] = s.options.minLength
+	}
+
+	if s.options.maxLength > 0 {
+		schema["maxLength"] = s.options.maxLength
+	}
+
+	return schema, nil
+}
+
+// alternation returns a group matching any of the separators.
+func alternation(separators []string) string {
+	seen := make(map[string]struct{}, len(separators))
+	quoted := make([]string, 0, len(separators))
+
+	for _, separator := range separators {
+		if _, ok := seen[separator]; ok || separator == "" {
+			continue
+		}
+
+		seen[separator] = struct{}{}
+		quoted = append(quoted, regexp.QuoteMeta(separator))
+	}
+
+	sort.Strings(quoted)
+
+	return "(?:" + strings.Join(quoted, "|") + ")"
+}
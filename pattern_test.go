@@ -0,0 +1,120 @@
+package sluggable
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gonstruct/sluggable/conformance"
+)
+
+func TestSluggable_Pattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		want    string
+		wantErr bool
+	}{
+		{name: "default", want: `^[a-z0-9_]+(?:(?:-)[a-z0-9_]+)*$`},
+		{name: "suffix separator", options: []sluggableOption{WithSuffixSeparator(".")}, want: `^[a-z0-9_]+(?:(?:-|\.)[a-z0-9_]+)*$`},
+		{name: "random fallback", options: []sluggableOption{WithMaxCollisionSuffix(9)}, want: `^[a-z0-9_0-9A-Za-z]+(?:(?:-)[a-z0-9_0-9A-Za-z]+)*$`},
+		{
+			name:    "extension",
+			options: []sluggableOption{WithPreset(KubernetesName()), WithPreserveExtension()},
+			want:    `^[a-z0-9]+(?:(?:-)[a-z0-9]+)*(?:\.[a-z0-9]{1,16})?$`,
+		},
+		{name: "custom method", options: []sluggableOption{WithMethod(verbatimMethod)}, wantErr: true},
+		{name: "custom suffix", options: []sluggableOption{WithSuffixFunc(func(string, int) string { return "x" })}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.options...).Pattern()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sluggable.Pattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Pattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSluggable_Pattern_matchesGenerated(t *testing.T) {
+	cases, err := conformance.Cases(1)
+	if err != nil {
+		t.Fatalf("conformance.Cases() error = %v", err)
+	}
+
+	// The first candidate of every other value is taken, so both base and suffixed slugs are generated
+	calls := 0
+	takeFirst := func(context.Context, string) (bool, error) {
+		calls++
+
+		return calls > 1, nil
+	}
+
+	configurations := map[string][]sluggableOption{
+		"default":          nil,
+		"suffix separator": {WithSeparator("_"), WithSuffixSeparator(".")},
+		"kubernetes":       {WithPreset(KubernetesName())},
+		"email":            {WithPreset(EmailLocalPart())},
+		"file key":         {WithPreset(FileKey())},
+		"ulid":             {WithSuffixStrategy(ULIDSuffix)},
+		"fallback":         {WithFirstUniqueSuffix(3), WithMaxCollisionSuffix(2), WithEmptyFallback(FallbackUUID)},
+	}
+
+	for name, options := range configurations {
+		t.Run(name, func(t *testing.T) {
+			s := New(append(options, WithAvailabilityChecker(takeFirst))...)
+
+			pattern, err := s.Pattern()
+			if err != nil {
+				t.Fatalf("Sluggable.Pattern() error = %v", err)
+			}
+
+			matcher := regexp.MustCompile(pattern)
+
+			for _, c := range cases {
+				for i, value := range []string{c.Input, c.Input + ".PDF"} {
+					calls = i
+
+					result, err := s.GenerateDetailed(nil, value)
+					if err != nil {
+						t.Fatalf("Sluggable.GenerateDetailed(%q) error = %v", value, err)
+					}
+
+					// Values without any word have no base to describe
+					if result.Base == "" || strings.HasPrefix(result.Base, ".") {
+						continue
+					}
+
+					slug := result.Slug
+
+					if !matcher.MatchString(slug) {
+						t.Errorf("Sluggable.Pattern() = %v, doesn't match %q generated from %q", pattern, slug, value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSluggable_JSONSchema(t *testing.T) {
+	got, err := New(WithPreset(KubernetesName()), WithMinLength(3), WithEmptyFallback(FallbackError)).JSONSchema()
+	if err != nil {
+		t.Fatalf("Sluggable.JSONSchema() error = %v", err)
+	}
+
+	want := map[string]any{"type": "string", "pattern": `^[a-z0-9]+(?:(?:-)[a-z0-9]+)*$`, "minLength": 3, "maxLength": 63}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sluggable.JSONSchema() = %v, want %v", got, want)
+	}
+
+	if _, err := New(WithMethod(verbatimMethod)).JSONSchema(); err == nil {
+		t.Error("Sluggable.JSONSchema() should fail for custom methods")
+	}
+}
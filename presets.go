@@ -0,0 +1,160 @@
+package sluggable
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	slugify "github.com/gosimple/slug"
+)
+
+//nolint:gochecknoglobals
+var (
+	extensionPattern       = regexp.MustCompile(`^\.[a-z0-9]{1,16}$`)
+	invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+	repeatedDashes         = regexp.MustCompile(`-{2,}`)
+	nonAlphanumericRuns    = regexp.MustCompile(`[^a-z0-9]+`)
+
+	reservedHostnames = []string{
+		"www", "mail", "email", "webmail", "smtp", "imap", "pop", "pop3", "mx", "ftp", "sftp",
+		"ns", "ns1", "ns2", "dns", "api", "admin", "localhost", "autodiscover", "autoconfig", "status",
+	}
+
+	reservedHandles = []string{
+		"admin", "administrator", "root", "system", "support", "help", "staff", "moderator", "security",
+		"api", "www", "login", "logout", "signup", "settings", "me", "null", "undefined", "anonymous",
+	}
+)
+
+// Preset is a named bundle of options, e.g. an organization-wide slug policy. Presets can include
+// other presets through WithPreset.
+type Preset struct {
+	Name    string
+	Version string // Optional
+	Options []sluggableOption
+}
+
+func NewPreset(name string, options ...sluggableOption) Preset {
+	return Preset{Name: name, Options: options}
+}
+
+func (p Preset) String() string {
+	if p.Version == "" {
+		return p.Name
+	}
+
+	return p.Name + "@" + p.Version
+}
+
+// WithPreset applies the options of the preset in order. Options given after it override the preset.
+func WithPreset(preset Preset) sluggableOption {
+	return func(opts *options) {
+		for _, option := range preset.Options {
+			option(opts)
+		}
+
+		opts.presets = append(opts.presets[:len(opts.presets):len(opts.presets)], preset.String())
+	}
+}
+
+// FileKey is a preset for file names and object storage keys. The extension of the value is kept
+// and the uniqueness suffix is placed before it ("report-2.pdf").
+func FileKey() Preset {
+	return NewPreset("file-key", WithPreserveExtension(), WithSeparator("-"))
+}
+
+// KubernetesName is a preset for resource names following the RFC 1123 label rules:
+// at most 63 lowercase alphanumerics or "-", starting and ending with an alphanumeric.
+func KubernetesName() Preset {
+	return NewPreset("kubernetes-name", WithMethod(rfc1123Method), WithSeparator("-"), WithMaxLength(63))
+}
+
+// Hostname is a preset for subdomains: RFC 1123 labels that never use well-known host names like "www" or "mail".
+// Combine it with WithAvailabilityChecker to also check DNS.
+func Hostname() Preset {
+	return NewPreset("hostname", WithPreset(KubernetesName()), WithReserved(reservedHostnames...))
+}
+
+// EmailLocalPart is a preset for the part of an email address before the "@", built from a display name:
+// "Jane Doe" becomes "jane.doe", "jane.doe.2" on collisions, and never more than 64 characters.
+func EmailLocalPart() Preset {
+	return NewPreset("email-local-part", WithMethod(emailLocalPartMethod), WithSeparator("."), WithMaxLength(64))
+}
+
+func emailLocalPartMethod(value, separator string) string {
+	return emailLocalPartLangMethod(value, separator, defaultLang)
+}
+
+func emailLocalPartLangMethod(value, separator, lang string) string {
+	slug := slugify.MakeLang(value, lang)
+	slug = nonAlphanumericRuns.ReplaceAllString(slug, separator)
+
+	return strings.Trim(slug, separator)
+}
+
+// HandleRules is a preset for usernames and handles: 3 to 30 lowercase alphanumerics or "_", never starting
+// with a digit, with lookalike characters folded ("pаypal" with a Cyrillic "а" becomes "paypal") and reserved
+// names like "admin" or "support" suffixed. Values without enough letters fail with ErrSlugTooShort, check
+// handles chosen by users with Validate.
+func HandleRules() Preset {
+	return NewPreset("handle",
+		WithMethod(handleMethod), WithSeparator("_"), WithConfusableFolding(),
+		WithMinLength(3), WithEmptyFallback(FallbackError), WithMaxLength(30),
+		WithReserved(reservedHandles...),
+	)
+}
+
+func handleMethod(value, separator string) string {
+	return handleLangMethod(value, separator, defaultLang)
+}
+
+// handleLangMethod is the email local-part method without leading digits, so handles never look like ids.
+func handleLangMethod(value, separator, lang string) string {
+	return strings.TrimLeft(emailLocalPartLangMethod(value, separator, lang), "0123456789"+separator)
+}
+
+// GitBranch is a preset for git branch names built from issue titles. Slugs only contain lowercase
+// alphanumerics, "-" and "_", so they never break git check-ref-format rules ("..", "@{", ".lock", ...).
+// Pass a nil db and check uniqueness against the existing branches with WithAvailabilityChecker.
+func GitBranch() Preset {
+	return NewPreset("git-branch", WithMethod(getDefaultOptions().method), WithSeparator("-"), WithMaxLength(200))
+}
+
+// LaravelSluggable is a preset for tables shared with Laravel's eloquent-sluggable: taken slugs are numbered
+// from 1 ("my-post-1"), so both stacks continue the same sequence.
+func LaravelSluggable() Preset {
+	return NewPreset("laravel-sluggable", WithSeparator("-"), WithFirstUniqueSuffix(1))
+}
+
+// FriendlyIDHistory is a preset for tables shared with Rails' FriendlyId history module: the slugs in its
+// friendly_id_slugs table are taken, so new slugs never shadow URLs FriendlyId still redirects. The history
+// of every model counts, FriendlyId's UUID suffixes are kept as is.
+func FriendlyIDHistory() Preset {
+	return NewPreset("friendly-id-history", WithSeparator("-"), WithReservedFromTable("friendly_id_slugs", "slug"))
+}
+
+func rfc1123Method(value, separator string) string {
+	return rfc1123LangMethod(value, separator, defaultLang)
+}
+
+func rfc1123LangMethod(value, separator, lang string) string {
+	slug := slugify.MakeLang(value, lang)
+	slug = invalidLabelCharacters.ReplaceAllString(slug, "-")
+	slug = repeatedDashes.ReplaceAllString(slug, "-")
+
+	return strings.Trim(slug, "-")
+}
+
+// splitExtension separates a safe, lowercased extension from the value when preserveExtension is enabled.
+func (o options) splitExtension(value string) (string, string) {
+	if !o.preserveExtension {
+		return value, ""
+	}
+
+	extension := path.Ext(value)
+	if !extensionPattern.MatchString(strings.ToLower(extension)) || extension == value {
+		return value, ""
+	}
+
+	return strings.TrimSuffix(value, extension), strings.ToLower(extension)
+}
@@ -0,0 +1,429 @@
+package sluggable
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestFileKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		existing []string
+		wantArgs []driver.Value
+		want     string
+	}{
+		{
+			name:     "keeps the extension",
+			value:    "Quarterly Report.PDF",
+			wantArgs: []driver.Value{"quarterly-report.pdf", "quarterly-report-%.pdf"},
+			want:     "quarterly-report.pdf",
+		},
+		{
+			name:     "places the suffix before the extension",
+			value:    "Quarterly Report.pdf",
+			existing: []string{"quarterly-report.pdf", "quarterly-report-2.pdf", "quarterly-report-2.pdf.bak"},
+			wantArgs: []driver.Value{"quarterly-report.pdf", "quarterly-report-%.pdf"},
+			want:     "quarterly-report-3.pdf",
+		},
+		{
+			name:     "unsafe extensions are slugified with the name",
+			value:    "notes.t x t",
+			wantArgs: []driver.Value{"notes-t-x-t", "notes-t-x-t-%"},
+			want:     "notes-t-x-t",
+		},
+		{
+			name:     "no extension",
+			value:    "README",
+			wantArgs: []driver.Value{"readme", "readme-%"},
+			want:     "readme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for _, existing := range tt.existing {
+				rows.AddRow(existing, existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "files"`).
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(rows)
+
+			got, err := New(WithPreset(FileKey())).Generate(db, tt.value, WithTableName("files"))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubernetesName(t *testing.T) {
+	long := strings.Repeat("abc ", 30)
+	full := strings.Repeat("abc-", 15) + "abc"  // 63 characters
+	shorter := strings.Repeat("abc-", 15) + "a" // room for "-2"
+
+	t.Run("rfc 1123 characters", func(t *testing.T) {
+		s := New(WithPreset(KubernetesName()))
+		if got, _ := s.options.makeSlug("My_Service.API -- v2"); got != "my-service-api-v2" {
+			t.Errorf("makeSlug() = %v, want my-service-api-v2", got)
+		}
+	})
+
+	t.Run("truncates to 63 characters", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "deployments"`).
+			WithArgs(full, full+"-%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+		got, err := New(WithPreset(KubernetesName())).Generate(db, long, WithTableName("deployments"))
+		if err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+
+		if got != full {
+			t.Errorf("Sluggable.Generate() = %v, want %v", got, full)
+		}
+	})
+
+	t.Run("makes room for the suffix", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to create mock database: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "deployments"`).
+			WithArgs(full, full+"-%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", full))
+		mock.ExpectQuery(`SELECT "id", "slug" FROM "deployments"`).
+			WithArgs(shorter, shorter+"-%").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", full).AddRow("2", shorter))
+
+		got, err := New(WithPreset(KubernetesName())).Generate(db, long, WithTableName("deployments"))
+		if err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+
+		if got != shorter+"-2" || len(got) > 63 {
+			t.Errorf("Sluggable.Generate() = %v, want %v", got, shorter+"-2")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("There were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestHostname(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		existing    []string
+		checker     func(ctx context.Context, slug string) (bool, error)
+		want        string
+		errContains string
+	}{
+		{name: "regular subdomain", value: "Acme Corp", want: "acme-corp"},
+		{name: "reserved names are suffixed", value: "WWW", want: "www-2"},
+		{name: "reserved and taken", value: "Mail", existing: []string{"mail-2"}, want: "mail-3"},
+		{
+			name:  "checker rejects a candidate",
+			value: "Acme",
+			checker: func(ctx context.Context, slug string) (bool, error) {
+				return slug != "acme", nil
+			},
+			want: "acme-2",
+		},
+		{
+			name:  "checker errors are returned",
+			value: "Acme",
+			checker: func(ctx context.Context, slug string) (bool, error) {
+				return false, fmt.Errorf("dns timeout")
+			},
+			errContains: "failed to check availability",
+		},
+		{
+			name:  "gives up when nothing is available",
+			value: "Acme",
+			checker: func(ctx context.Context, slug string) (bool, error) {
+				return false, nil
+			},
+			errContains: "no available slug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for _, existing := range tt.existing {
+				rows.AddRow(existing, existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "tenants"`).WillReturnRows(rows)
+
+			s := New(WithPreset(Hostname()), WithAvailabilityChecker(tt.checker))
+
+			got, err := s.Generate(db, tt.value, WithTableName("tenants"))
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Sluggable.Generate() error = %v, want error containing %v", err, tt.errContains)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmailLocalPart(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		existing []string
+		want     string
+	}{
+		{name: "display name", value: "Jane Doe", want: "jane.doe"},
+		{name: "punctuation collapses to a single dot", value: "  O'Brien--Smith, Jr. ", want: "obrien.smith.jr"},
+		{name: "transliterates", value: "Zoë Ærøskøbing", want: "zoe.aeroskobing"},
+		{name: "collisions are suffixed", value: "Jane Doe", existing: []string{"jane.doe"}, want: "jane.doe.2"},
+		{name: "limited to 64 characters", value: strings.Repeat("a", 70), want: strings.Repeat("a", 64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for _, existing := range tt.existing {
+				rows.AddRow(existing, existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "local_part" FROM "mailboxes"`).WillReturnRows(rows)
+
+			got, err := New(WithPreset(EmailLocalPart())).Generate(db, tt.value, WithTableName("mailboxes"), WithColumnName("local_part"))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		existing []string
+		want     string
+		wantErr  error
+	}{
+		{name: "display name", value: "Jane Doe", want: "jane_doe"},
+		{name: "leading digits are dropped", value: "42 Jane", want: "jane"},
+		{name: "lookalikes are folded", value: "pаypal", want: "paypal"},
+		{name: "reserved names are suffixed", value: "Admin", want: "admin_2"},
+		{name: "collisions are suffixed", value: "Jane", existing: []string{"jane"}, want: "jane_2"},
+		{name: "limited to 30 characters", value: strings.Repeat("a", 40), want: strings.Repeat("a", 30)},
+		{name: "at least 3 characters", value: "Al", wantErr: ErrSlugTooShort},
+		{name: "digits only", value: "2024", wantErr: ErrSlugTooShort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for _, existing := range tt.existing {
+				rows.AddRow(existing, existing)
+			}
+
+			if tt.wantErr == nil {
+				mock.ExpectQuery(`SELECT "id", "handle" FROM "users"`).WillReturnRows(rows)
+			}
+
+			got, err := New(WithPreset(HandleRules())).Generate(db, tt.value, WithTableName("users"), WithColumnName("handle"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sluggable.Generate() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	s := New(WithPreset(HandleRules()))
+
+	for handle, wantErr := range map[string]error{
+		"jane_doe":              nil,
+		"support":               ErrInvalidSlug,
+		"1jane":                 ErrInvalidSlug,
+		"Jane":                  ErrInvalidSlug,
+		"jo":                    ErrSlugTooShort,
+		strings.Repeat("a", 31): ErrInvalidSlug,
+	} {
+		if err := s.Validate(handle); !errors.Is(err, wantErr) {
+			t.Errorf("Sluggable.Validate(%q) error = %v, want %v", handle, err, wantErr)
+		}
+	}
+}
+
+func TestGitBranch(t *testing.T) {
+	branches := map[string]bool{"fix-login-redirect": true, "fix-login-redirect-2": true}
+	checker := WithAvailabilityChecker(func(ctx context.Context, slug string) (bool, error) {
+		return !branches[slug], nil
+	})
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "issue title", value: "Add OAuth: support for GitHub?", want: "add-oauth-support-for-github"},
+		{name: "taken branches are suffixed", value: "Fix login redirect", want: "fix-login-redirect-3"},
+		{name: "ref format characters", value: "..hotfix ~1 ^HEAD @{wip}.lock", want: "hotfix-1-head-at-wip-lock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(WithPreset(GitBranch()), checker).Generate(nil, tt.value)
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("nil db needs a checker", func(t *testing.T) {
+		if _, err := New(WithPreset(GitBranch())).Generate(nil, "Fix login redirect"); err == nil {
+			t.Error("Sluggable.Generate() should fail without db and availability checker")
+		}
+	})
+}
+
+func TestWithPreset(t *testing.T) {
+	policy := NewPreset("acme", WithPreset(KubernetesName()), WithSeparator("_"), WithFirstUniqueSuffix(1))
+	policy.Version = "2"
+
+	s := New(WithPreset(policy), WithFirstUniqueSuffix(5))
+	got := s.Options()
+
+	if strings.Join(got.Presets, ",") != "kubernetes-name,acme@2" {
+		t.Errorf("Options().Presets = %v, want [kubernetes-name acme@2]", got.Presets)
+	}
+
+	if got.Separator != "_" || got.MaxLength != 63 {
+		t.Errorf("Options() = %+v, want separator from acme and max length from kubernetes-name", got)
+	}
+
+	if got.FirstUniqueSuffix != 5 {
+		t.Errorf("Options().FirstUniqueSuffix = %v, want options after the preset to win", got.FirstUniqueSuffix)
+	}
+}
+
+func TestLaravelSluggable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT`).
+		WithArgs("my-post", "my-post-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow(1, "my-post"))
+
+	got, err := New(WithPreset(LaravelSluggable())).Generate(db, "My Post", WithTableName("posts"))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "my-post-1" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "my-post-1")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestFriendlyIDHistory(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "posts" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("my-post", "my-post-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow(1, "my-post-0b6f3b6e-5f0c-4bb1-9a4a-3c1d2e5f6a7b"))
+	mock.ExpectQuery(`SELECT "slug" FROM "friendly_id_slugs" WHERE ("slug" = $1 OR "slug" LIKE $2)`).
+		WithArgs("my-post", "my-post-%").
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("my-post"))
+
+	got, err := New(WithPreset(FriendlyIDHistory())).Generate(db, "My Post", WithTableName("posts"))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "my-post-2" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "my-post-2")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,36 @@
+package sluggable
+
+import "context"
+
+type previewKey struct{}
+
+// WithPreview generates without side effects, e.g. for a live slug preview while typing: no holds are
+// taken and no advisory lock is acquired. Availability checkers and suffix functions can check IsPreview
+// to skip their own side effects, like consuming a sequence value. Suggest and Simulate always run as a preview.
+func WithPreview() sluggableOption {
+	return func(opts *options) {
+		opts.preview = true
+	}
+}
+
+// IsPreview reports whether the context belongs to a preview generation, see WithPreview.
+func IsPreview(ctx context.Context) bool {
+	preview, _ := ctx.Value(previewKey{}).(bool)
+
+	return preview
+}
+
+// previewContext marks the context as a preview when preview or read-only mode is enabled.
+func (o options) previewContext(ctx context.Context) context.Context {
+	if !o.sideEffectFree() {
+		return ctx
+	}
+
+	return context.WithValue(ctx, previewKey{}, true)
+}
+
+// sideEffectFree reports whether holds, locks and the statement timeout are skipped. Unlike previews,
+// read-only generations are still logged and warned about.
+func (o options) sideEffectFree() bool {
+	return o.preview || o.readOnly
+}
@@ -0,0 +1,59 @@
+package sluggable
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithPreview(t *testing.T) {
+	holds := NewHolds(time.Minute)
+
+	var previews []bool
+
+	checker := func(ctx context.Context, slug string) (bool, error) {
+		previews = append(previews, IsPreview(ctx))
+
+		return true, nil
+	}
+
+	s := New(WithHolds(holds), WithAvailabilityChecker(checker))
+
+	got, err := s.Generate(nil, "Hello World", WithIdentifier("1"), WithPreview())
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world")
+	}
+
+	if holds.isHeld("hello-world", "2") {
+		t.Errorf("Sluggable.Generate() with preview held %v, want no hold", got)
+	}
+
+	if _, err := s.Suggest(nil, "Hello World", 2, WithIdentifier("1")); err != nil {
+		t.Fatalf("Sluggable.Suggest() error = %v", err)
+	}
+
+	if _, err := s.Generate(nil, "Hello World", WithIdentifier("1")); err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	want := []bool{true, true, true, false}
+	if len(previews) != len(want) {
+		t.Fatalf("IsPreview() calls = %v, want %v", previews, want)
+	}
+
+	for i := range want {
+		if previews[i] != want[i] {
+			t.Errorf("IsPreview() calls = %v, want %v", previews, want)
+
+			break
+		}
+	}
+
+	if !holds.isHeld("hello-world", "2") {
+		t.Errorf("Sluggable.Generate() without preview didn't hold %v", "hello-world")
+	}
+}
@@ -0,0 +1,53 @@
+package sluggable
+
+// QueryParts are the pieces of the query looking up similar slugs, passed to WithQueryBuilder.
+// Identifiers are quoted for the dialect, placeholders are numbered in the order of Args. Wildcards in
+// the slug are escaped with "!" in LikePattern, add ESCAPE '!' when it contains one.
+type QueryParts struct {
+	Dialect          Dialect
+	Table            string // Qualified with the schema when one is set
+	IdentifierColumn string
+	Column           string // Lowered with WithCaseInsensitive
+	Slug             string // Matched with Column = Slug
+	LikePattern      string // Matched with Column LIKE LikePattern, covers the suffixed slugs
+	Wheres           []string
+	Args             []any // Slug, LikePattern and the arguments of the Wheres
+
+	// Query is the query that runs without a builder, e.g. to wrap it
+	Query string
+}
+
+// WithQueryBuilder replaces the query looking up similar slugs, e.g. for CTEs, views or partitioned tables.
+// The query must select the identifier and the slug of the rows matching the slug or the like pattern,
+// decoded like any other rows (see WithRowDecoder). The suffix algorithm runs on the result unchanged.
+func WithQueryBuilder(build func(q QueryParts) (sql string, args []any)) sluggableOption {
+	return func(opts *options) {
+		opts.queryBuilder = build
+	}
+}
+
+// buildCustomSimilarQuery passes the parts of the similar slugs query to the query builder.
+func buildCustomSimilarQuery(opts options, slug, query string) (string, []any, error) {
+	dialect := opts.getDialect()
+
+	exact, pattern := opts.foldCase(opts.unsuffixed(slug)), opts.foldCase(opts.likePattern(slug))
+
+	wheres, args, err := buildWhereConditions(opts, []any{exact, pattern}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	custom, customArgs := opts.queryBuilder(QueryParts{
+		Dialect:          dialect,
+		Table:            opts.qualifiedTable(),
+		IdentifierColumn: dialect.quote(opts.identifierColumn),
+		Column:           opts.slugColumn(),
+		Slug:             exact,
+		LikePattern:      pattern,
+		Wheres:           wheres,
+		Args:             args,
+		Query:            query,
+	})
+
+	return custom, customArgs, nil
+}
@@ -0,0 +1,56 @@
+package sluggable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithQueryBuilder(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	var parts QueryParts
+
+	builder := func(q QueryParts) (string, []any) {
+		parts = q
+
+		return fmt.Sprintf(`SELECT %s, %s FROM articles_2024 WHERE (%s = $1 OR %s LIKE $2) AND %s UNION ALL SELECT %s, %s FROM articles_2023 WHERE %s = $1`,
+			q.IdentifierColumn, q.Column, q.Column, q.Column, strings.Join(q.Wheres, " AND "), q.IdentifierColumn, q.Column, q.Column,
+		), q.Args
+	}
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM articles_2024 WHERE ("slug" = $1 OR "slug" LIKE $2) AND "deleted_at" IS NULL AND "status" = $3 `+
+		`UNION ALL SELECT "id", "slug" FROM articles_2023 WHERE "slug" = $1`).
+		WithArgs("hello-world", "hello-world-%", "published").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow(1, "hello-world").AddRow(2, "hello-world-2"))
+
+	s := New(WithQueryBuilder(builder))
+
+	got, err := s.Generate(db, "Hello World", WithTableName("articles"), WithWhere(`"status" = ?`, "published"))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world-3" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-3")
+	}
+
+	wantQuery := `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("status" = $3)`
+	if parts.Query != wantQuery {
+		t.Errorf("QueryParts.Query = %v, want %v", parts.Query, wantQuery)
+	}
+
+	if parts.Table != `"articles"` || parts.Slug != "hello-world" || parts.LikePattern != "hello-world-%" {
+		t.Errorf("QueryParts = %+v, want table, slug and like pattern", parts)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
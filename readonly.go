@@ -0,0 +1,22 @@
+package sluggable
+
+import "fmt"
+
+// WithReadOnly guarantees that no write is ever executed, e.g. to audit slugs against a production replica.
+// GenerateAndSave, GenerateWith, ScheduleChange and ApplyDue fail with ErrReadOnly. Generations take no holds,
+// locks or statement timeouts and their context is marked like a preview (see IsPreview), but unlike previews
+// they still reach WithDecisionLog and WithSuffixWarning.
+func WithReadOnly() sluggableOption {
+	return func(opts *options) {
+		opts.readOnly = true
+	}
+}
+
+// checkWritable returns ErrReadOnly for the named write when read-only mode is enabled.
+func (o options) checkWritable(operation string) error {
+	if !o.readOnly {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s is not allowed", ErrReadOnly, operation)
+}
@@ -0,0 +1,113 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithReadOnly(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	s := New(WithReadOnly(), WithTableName("articles"), WithIdentifier("1"), WithPendingTable("pending_slugs"))
+	ctx := context.Background()
+
+	writes := []struct {
+		name string
+		run  func() error
+	}{
+		{name: "GenerateAndSave", run: func() error {
+			_, err := s.GenerateAndSave(ctx, db, "Hello World")
+			return err
+		}},
+		{name: "GenerateWith", run: func() error {
+			_, err := s.GenerateWith(db, "Hello World", func(string) error { return nil })
+			return err
+		}},
+		{name: "ScheduleChange", run: func() error {
+			_, err := s.ScheduleChange(ctx, db, "Hello World", time.Now())
+			return err
+		}},
+		{name: "ApplyDue", run: func() error {
+			_, err := s.ApplyDue(ctx, db)
+			return err
+		}},
+	}
+
+	for _, write := range writes {
+		if err := write.run(); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("Sluggable.%s() error = %v, want %v", write.name, err, ErrReadOnly)
+		}
+	}
+
+	// Lookups run without row locks
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("2", "hello-world"))
+
+	holds := NewHolds(time.Minute)
+
+	got, err := s.Generate(db, "Hello World", WithLock(LockForUpdate), WithHolds(holds))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world-2" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-2")
+	}
+
+	if holds.isHeld("hello-world-2", "3") {
+		t.Errorf("Sluggable.Generate() with read-only held %v, want no hold", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithReadOnlyKeepsAuditing(t *testing.T) {
+	var (
+		decisions []Decision
+		warnings  []Result
+		previews  []bool
+	)
+
+	s := New(
+		WithReadOnly(),
+		WithDecisionLog(func(_ context.Context, decision Decision) { decisions = append(decisions, decision) }),
+		WithSuffixWarning(1, func(_ context.Context, result Result) { warnings = append(warnings, result) }),
+		WithAvailabilityChecker(func(ctx context.Context, slug string) (bool, error) {
+			previews = append(previews, IsPreview(ctx))
+
+			return slug != "hello-world", nil
+		}),
+	)
+
+	got, err := s.Generate(nil, "Hello World")
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world-2" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-2")
+	}
+
+	if len(decisions) != 1 || decisions[0].Slug != "hello-world-2" {
+		t.Errorf("decisions = %+v, want one for %v", decisions, "hello-world-2")
+	}
+
+	if len(warnings) != 1 || warnings[0].Suffix != 2 {
+		t.Errorf("warnings = %+v, want one for suffix %d", warnings, 2)
+	}
+
+	if len(previews) == 0 || !previews[0] {
+		t.Errorf("IsPreview() in the availability checker = %v, want true", previews)
+	}
+}
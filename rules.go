@@ -0,0 +1,57 @@
+package sluggable
+
+import (
+	"fmt"
+	"sort"
+
+	slugify "github.com/gosimple/slug"
+)
+
+// LatestRulesVersion is the newest rule set, used by WithRulesVersion(LatestRulesVersion).
+const LatestRulesVersion = 1
+
+// rulesVersions maps every released rule set to its slug method. Released versions never change,
+// new behavior is added as a new version.
+//
+//nolint:gochecknoglobals
+var rulesVersions = map[int]func(value, separator string) string{
+	1: rulesV1,
+}
+
+// rulesV1 transliterates with the English rules of github.com/gosimple/slug.
+func rulesV1(value, separator string) string {
+	return rulesV1Lang(value, separator, defaultLang)
+}
+
+func rulesV1Lang(value, separator, lang string) string {
+	return slugify.MakeLang(value, lang)
+}
+
+// RulesVersions returns the released rule set versions in ascending order.
+func RulesVersions() []int {
+	versions := make([]int, 0, len(rulesVersions))
+	for version := range rulesVersions {
+		versions = append(versions, version)
+	}
+
+	sort.Ints(versions)
+
+	return versions
+}
+
+// WithRulesVersion pins the slug method to a released rule set, so upgrading the library never changes
+// the slugs generated for the same input. A later WithMethod replaces the rule set.
+func WithRulesVersion(version int) sluggableOption {
+	return func(opts *options) {
+		opts.rulesVersion = version
+		opts.method = rulesVersions[version]
+	}
+}
+
+func validateRulesVersion(version int) error {
+	if _, ok := rulesVersions[version]; version != 0 && !ok {
+		return fmt.Errorf("[sluggable] unknown rules version %d, latest is %d", version, LatestRulesVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package sluggable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gonstruct/sluggable/conformance"
+)
+
+func TestWithRulesVersion(t *testing.T) {
+	s := New(WithRulesVersion(1))
+
+	if got := s.options.slugify("Crème Brûlée & Co."); got != "creme-brulee-and-co" {
+		t.Errorf("slugify() = %v, want %v", got, "creme-brulee-and-co")
+	}
+
+	if got := s.Options().RulesVersion; got != 1 {
+		t.Errorf("Options().RulesVersion = %v, want %v", got, 1)
+	}
+
+	// A custom method replaces the rule set
+	if got := New(WithRulesVersion(1), WithMethod(verbatimMethod)).Options().RulesVersion; got != 0 {
+		t.Errorf("Options().RulesVersion = %v, want %v", got, 0)
+	}
+
+	if _, err := NewStrict(WithRulesVersion(LatestRulesVersion + 1)); err == nil || !strings.Contains(err.Error(), "unknown rules version") {
+		t.Errorf("NewStrict() error = %v, want unknown rules version", err)
+	}
+
+	if _, err := New(WithRulesVersion(99)).Generate(nil, "Hello"); err == nil || !strings.Contains(err.Error(), "unknown rules version") {
+		t.Errorf("Sluggable.Generate() error = %v, want unknown rules version", err)
+	}
+}
+
+func TestRulesVersions(t *testing.T) {
+	versions := RulesVersions()
+	if len(versions) == 0 || versions[len(versions)-1] != LatestRulesVersion {
+		t.Errorf("RulesVersions() = %v, want the latest version %d last", versions, LatestRulesVersion)
+	}
+}
+
+func TestRulesVersions_Conformance(t *testing.T) {
+	for _, version := range RulesVersions() {
+		s := New(WithRulesVersion(version))
+
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			conformance.Run(t, version, func(input string) (string, error) {
+				return s.options.slugify(input), nil
+			})
+		})
+	}
+}
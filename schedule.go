@@ -0,0 +1,213 @@
+package sluggable
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithPendingTable sets the table scheduled slug changes are stored in until they're due. The table needs
+// the columns "identifier", "slug" and "effective_at", in the schema of the sluggable table. The identifier
+// column has the type of the identifier column of the sluggable table.
+func WithPendingTable(pendingTable string) sluggableOption {
+	return func(opts *options) {
+		opts.pendingTable = pendingTable
+	}
+}
+
+// ScheduleChange generates the slug for the value and stores it in the pending table, to be applied by
+// ApplyDue once effectiveAt has passed, e.g. to coordinate a URL change with a launch. Requires WithIdentifier
+// and WithPendingTable. WithApproval is asked when the change is scheduled. The slug isn't reserved in the
+// meantime, ApplyDue fails on the unique index when another record took it.
+func (s *Sluggable) ScheduleChange(ctx context.Context, db contextExecutor, value string, effectiveAt time.Time, options ...sluggableOption) (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("[sluggable] db cannot be nil when scheduling")
+	}
+
+	opts, err := s.resolvePendingOptions(ctx, db, options)
+	if err != nil {
+		return "", err
+	}
+
+	if err := opts.checkWritable("scheduling"); err != nil {
+		return "", err
+	}
+
+	if identifierString(opts.identifier) == "" {
+		return "", fmt.Errorf("[sluggable] scheduling requires an identifier")
+	}
+
+	result, err := generateDetailed(ctx, db, opts, value)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.approval != nil {
+		current, err := queryCurrentSlug(ctx, db, opts)
+		if err != nil {
+			return "", err
+		}
+
+		if err := opts.approveChange(current, result.Slug); err != nil {
+			return "", err
+		}
+	}
+
+	query := buildScheduleQuery(opts)
+	params := []any{opts.identifier, result.Slug, effectiveAt}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", params)
+	}
+
+	if _, err := db.ExecContext(ctx, query, params...); err != nil {
+		return "", fmt.Errorf("[sluggable] failed to schedule slug: %w", err)
+	}
+
+	return result.Slug, nil
+}
+
+// ApplyDue stores the scheduled slugs whose effective time has passed and removes them from the pending
+// table, oldest first. Run it periodically, inside a transaction so a failed change leaves nothing half
+// applied. Returns the number of applied changes.
+func (s *Sluggable) ApplyDue(ctx context.Context, db contextExecutor, options ...sluggableOption) (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("[sluggable] db cannot be nil when applying scheduled slugs")
+	}
+
+	opts, err := s.resolvePendingOptions(ctx, db, options)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := opts.checkWritable("applying scheduled slugs"); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	// Due changes stay pending and are applied by the first run after the freeze
+	if err := opts.checkFrozen(now); err != nil {
+		return 0, err
+	}
+
+	due, err := queryDue(ctx, db, opts, now)
+	if err != nil {
+		return 0, err
+	}
+
+	update, err := buildUpdateQuery(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	remove := buildRemovePendingQuery(opts)
+
+	for i, change := range due {
+		if opts.debug {
+			fmt.Printf("[sluggable] %s\n", update)
+			fmt.Printf("[sluggable] %v\n", []any{change.slug, change.identifier})
+		}
+
+		if _, err := db.ExecContext(ctx, update, change.slug, change.identifier); err != nil {
+			return i, fmt.Errorf("[sluggable] failed to apply scheduled slug %q: %w", change.slug, err)
+		}
+
+		if _, err := db.ExecContext(ctx, remove, change.identifier, change.slug); err != nil {
+			return i, fmt.Errorf("[sluggable] failed to remove scheduled slug %q: %w", change.slug, err)
+		}
+	}
+
+	return len(due), nil
+}
+
+type scheduledChange struct {
+	identifier any // As scanned, so integer identifier columns are bound as integers
+	slug       string
+}
+
+func (s *Sluggable) resolvePendingOptions(ctx context.Context, db contextExecutor, options []sluggableOption) (options, error) {
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return opts, err
+	}
+
+	if opts.pendingTable == "" {
+		return opts, fmt.Errorf("[sluggable] pending table cannot be empty")
+	}
+
+	return opts, opts.validateIdentifiers()
+}
+
+// queryDue reads all due changes before applying any, since not every driver can execute statements
+// while rows of the same transaction are still open.
+func queryDue(ctx context.Context, db contextExecutor, opts options, now time.Time) ([]scheduledChange, error) {
+	query := buildDueQuery(opts)
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", []any{now})
+	}
+
+	rows, err := db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to query scheduled slugs: %w", err)
+	}
+	defer rows.Close()
+
+	var due []scheduledChange
+
+	for rows.Next() {
+		var change scheduledChange
+		if err := rows.Scan(&change.identifier, &change.slug); err != nil {
+			return nil, fmt.Errorf("[sluggable] failed to scan scheduled slug: %w", err)
+		}
+
+		due = append(due, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to iterate scheduled slugs: %w", err)
+	}
+
+	return due, nil
+}
+
+func (o options) qualifiedPendingTable() string {
+	dialect := o.getDialect()
+
+	table := dialect.quote(o.pendingTable)
+	if o.schema != "" {
+		table = dialect.quote(o.schema) + "." + table
+	}
+
+	return table
+}
+
+func buildScheduleQuery(opts options) string {
+	dialect := opts.getDialect()
+
+	return fmt.Sprintf(`INSERT INTO %s (%s, %s, %s) VALUES (%s, %s, %s)`,
+		opts.qualifiedPendingTable(), dialect.quote("identifier"), dialect.quote("slug"), dialect.quote("effective_at"),
+		dialect.placeholder(1), dialect.placeholder(2), dialect.placeholder(3),
+	)
+}
+
+func buildDueQuery(opts options) string {
+	dialect := opts.getDialect()
+
+	return fmt.Sprintf(`SELECT %s, %s FROM %s WHERE %s <= %s ORDER BY %s`,
+		dialect.quote("identifier"), dialect.quote("slug"), opts.qualifiedPendingTable(),
+		dialect.quote("effective_at"), dialect.placeholder(1), dialect.quote("effective_at"),
+	)
+}
+
+func buildRemovePendingQuery(opts options) string {
+	dialect := opts.getDialect()
+
+	return fmt.Sprintf(`DELETE FROM %s WHERE %s = %s AND %s = %s`,
+		opts.qualifiedPendingTable(), dialect.quote("identifier"), dialect.placeholder(1),
+		dialect.quote("slug"), dialect.placeholder(2),
+	)
+}
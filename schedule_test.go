@@ -0,0 +1,148 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSluggable_ScheduleChange(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	effectiveAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "summer-sale"))
+	mock.ExpectExec(`INSERT INTO "slug_changes" ("identifier", "slug", "effective_at") VALUES ($1, $2, $3)`).
+		WithArgs(42, "summer-sale-2", effectiveAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	s := New(WithTableName("articles"), WithPendingTable("slug_changes"))
+
+	got, err := s.ScheduleChange(context.Background(), db, "Summer Sale", effectiveAt, WithIdentifier(42))
+	if err != nil {
+		t.Fatalf("Sluggable.ScheduleChange() error = %v", err)
+	}
+
+	if got != "summer-sale-2" {
+		t.Errorf("Sluggable.ScheduleChange() = %v, want %v", got, "summer-sale-2")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	if _, err := New(WithTableName("articles")).ScheduleChange(context.Background(), db, "Summer Sale", effectiveAt, WithIdentifier(42)); err == nil {
+		t.Error("Sluggable.ScheduleChange() should require a pending table")
+	}
+
+	if _, err := s.ScheduleChange(context.Background(), db, "Summer Sale", effectiveAt); err == nil {
+		t.Error("Sluggable.ScheduleChange() should require an identifier")
+	}
+
+	if _, err := s.ScheduleChange(context.Background(), db, "Summer Sale", effectiveAt, WithIdentifier(42), WithPendingTable("x;y")); err == nil {
+		t.Error("Sluggable.ScheduleChange() should validate the pending table")
+	}
+}
+
+func TestSluggable_ApplyDue(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT `identifier`, `slug` FROM `cms`.`slug_changes` WHERE `effective_at` <= ? ORDER BY `effective_at`").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"identifier", "slug"}).AddRow(int64(42), "summer-sale").AddRow(int64(7), "launch"))
+
+	// Integer identifiers are bound as integers, not as strings
+	for _, change := range []scheduledChange{{identifier: int64(42), slug: "summer-sale"}, {identifier: int64(7), slug: "launch"}} {
+		mock.ExpectExec("UPDATE `cms`.`articles` SET `slug` = ? WHERE `id` = ?").
+			WithArgs(change.slug, change.identifier).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM `cms`.`slug_changes` WHERE `identifier` = ? AND `slug` = ?").
+			WithArgs(change.identifier, change.slug).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	s := New(WithTableName("articles"), WithSchema("cms"), WithPendingTable("slug_changes"), WithDialect(MySQL))
+
+	got, err := s.ApplyDue(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Sluggable.ApplyDue() error = %v", err)
+	}
+
+	if got != 2 {
+		t.Errorf("Sluggable.ApplyDue() = %v, want %v", got, 2)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestScheduleChangeApproval(t *testing.T) {
+	tests := []struct {
+		name     string
+		approved bool
+		wantErr  error
+	}{
+		{name: "approved", approved: true},
+		{name: "rejected", approved: false, wantErr: ErrChangeNotApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			effectiveAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+			mock.ExpectQuery(`SELECT "slug" FROM "articles" WHERE "id" = $1`).
+				WithArgs(42).
+				WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("winter-sale"))
+
+			if tt.wantErr == nil {
+				mock.ExpectExec(`INSERT INTO "slug_changes" ("identifier", "slug", "effective_at") VALUES ($1, $2, $3)`).
+					WithArgs(42, "summer-sale", effectiveAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			var asked []string
+
+			approve := func(old, new string) (bool, error) {
+				asked = append(asked, old+" -> "+new)
+
+				return tt.approved, nil
+			}
+
+			s := New(WithTableName("articles"), WithPendingTable("slug_changes"), WithApproval(approve))
+
+			_, err = s.ScheduleChange(context.Background(), db, "Summer Sale", effectiveAt, WithIdentifier(42))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Sluggable.ScheduleChange() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(asked) != 1 || asked[0] != "winter-sale -> summer-sale" {
+				t.Errorf("approval asked = %v, want %v", asked, []string{"winter-sale -> summer-sale"})
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
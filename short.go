@@ -0,0 +1,89 @@
+package sluggable
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+const (
+	base62Alphabet       = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	maxShortLinkAttempts = 5
+)
+
+// EncodeBase62 encodes a counter value as a compact base62 string.
+func EncodeBase62(value uint64) string {
+	if value == 0 {
+		return base62Alphabet[:1]
+	}
+
+	var encoded []byte
+	for value > 0 {
+		encoded = append([]byte{base62Alphabet[value%62]}, encoded...)
+		value /= 62
+	}
+
+	return string(encoded)
+}
+
+func randomBase62(length int) (string, error) {
+	encoded := make([]byte, length)
+	alphabetSize := big.NewInt(int64(len(base62Alphabet)))
+
+	for i := range encoded {
+		index, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("[sluggable] failed to generate random value: %w", err)
+		}
+
+		encoded[i] = base62Alphabet[index.Int64()]
+	}
+
+	return string(encoded), nil
+}
+
+// GenerateShort generates a random base62 slug of the given length that isn't taken yet.
+// A taken value is replaced by a new random value instead of being suffixed, only the returned slug is held.
+func (s *Sluggable) GenerateShort(db contextExecutor, length int, options ...sluggableOption) (string, error) {
+	return s.GenerateShortContext(context.Background(), db, length, options...)
+}
+
+// GenerateShortContext is like GenerateShort with a context.
+func (s *Sluggable) GenerateShortContext(ctx context.Context, db contextExecutor, length int, options ...sluggableOption) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("[sluggable] short link length must be positive")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, append(options[:len(options):len(options)], WithMethod(verbatimMethod)))
+	if err != nil {
+		return "", err
+	}
+
+	// Collided values are discarded, so only the returned slug is held
+	opts.deferHold = true
+
+	for attempt := 0; attempt < maxShortLinkAttempts; attempt++ {
+		value, err := randomBase62(length)
+		if err != nil {
+			return "", err
+		}
+
+		result, err := generateDetailed(ctx, db, opts, value)
+		if err != nil {
+			return "", err
+		}
+
+		if result.HadCollision {
+			continue
+		}
+
+		if opts.holds != nil && !opts.sideEffectFree() {
+			opts.holds.hold(result.Slug, identifierString(opts.identifier))
+		}
+
+		return result.Slug, nil
+	}
+
+	return "", fmt.Errorf("[sluggable] no available short link after %d attempts", maxShortLinkAttempts)
+}
@@ -0,0 +1,100 @@
+package sluggable
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestEncodeBase62(t *testing.T) {
+	tests := map[uint64]string{
+		0:       "0",
+		61:      "z",
+		62:      "10",
+		3843:    "zz",
+		1000000: "4C92",
+	}
+
+	for value, want := range tests {
+		if got := EncodeBase62(value); got != want {
+			t.Errorf("EncodeBase62(%d) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestSluggable_GenerateShort(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "links"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "taken"))
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "links"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	got, err := New().GenerateShort(db, 8, WithTableName("links"))
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateShort() error = %v", err)
+	}
+
+	if len(got) != 8 || strings.Trim(got, base62Alphabet) != "" {
+		t.Errorf("Sluggable.GenerateShort() = %v, want 8 base62 characters", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	if _, err := New().GenerateShort(db, 0, WithTableName("links")); err == nil {
+		t.Error("Sluggable.GenerateShort() should reject a non-positive length")
+	}
+}
+
+func TestGenerateShortHolds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	// The first value collides, its suffixed candidate is discarded
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "links"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "taken"))
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "links"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	holds := NewHolds(time.Minute)
+
+	// The caller's slice has spare capacity, GenerateShort must not write into it
+	callerOptions := make([]sluggableOption, 1, 2)
+	callerOptions[0] = WithTableName("links")
+	spare := callerOptions[:2]
+	spare[1] = WithAvailabilityChecker(func(context.Context, string) (bool, error) { return true, nil })
+
+	got, err := New(WithHolds(holds)).GenerateShort(db, 8, callerOptions...)
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateShort() error = %v", err)
+	}
+
+	if len(holds.held) != 1 || !holds.isHeld(got, "") {
+		t.Errorf("holds = %v, want only %v held", holds.held, got)
+	}
+
+	var applied options
+	if spare[1](&applied); applied.availabilityChecker == nil {
+		t.Error("Sluggable.GenerateShort() overwrote the spare capacity of the options")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,145 @@
+package sluggable
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SimulatedChange is an existing slug that would change under the simulated configuration.
+type SimulatedChange struct {
+	Identifier string
+	Value      string // Value of the source column
+	Current    string
+	Simulated  string
+}
+
+// Simulate regenerates the slug of every row from the column set with WithSourceColumn, as if the records
+// were saved again one after another with the given options, and returns the rows whose slug would change.
+// Nothing is written and the run is a preview, see WithPreview. All matching rows are loaded with a single query, so narrow large tables down with WithWhere.
+func (s *Sluggable) Simulate(ctx context.Context, db contextExecutor, options ...sluggableOption) ([]SimulatedChange, error) {
+	if db == nil {
+		return nil, fmt.Errorf("[sluggable] db cannot be nil when simulating")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.sourceColumn == "" {
+		return nil, fmt.Errorf("[sluggable] simulating requires a source column")
+	}
+
+	opts.preview = true
+	ctx = opts.previewContext(ctx)
+
+	rows, err := queryAll(ctx, db, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []SimulatedChange
+
+	// Regenerated slugs replace the current ones, so later rows collide with them instead
+	state := append([]SimulatedChange(nil), rows...)
+
+	for i, row := range rows {
+		rowOpts := opts
+		rowOpts.identifier = row.Identifier
+
+		rowOpts, slug, err := rowOpts.baseSlug(row.Value)
+		if err != nil {
+			return nil, fmt.Errorf("[sluggable] failed to simulate %s %q: %w", opts.identifierColumn, row.Identifier, err)
+		}
+
+		result, err := generateFitting(ctx, rowOpts, slug, func(slug string) (map[string]string, string, error) {
+			return rowOpts.similarIn(state, slug), "", nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[sluggable] failed to simulate %s %q: %w", opts.identifierColumn, row.Identifier, err)
+		}
+
+		state[i].Current = result.Slug
+
+		if result.Slug != row.Current {
+			row.Simulated = result.Slug
+			changes = append(changes, row)
+		}
+	}
+
+	return changes, nil
+}
+
+// queryAll loads the identifier, source value and current slug of every row matching the where clauses.
+func queryAll(ctx context.Context, db contextExecutor, opts options) ([]SimulatedChange, error) {
+	if err := opts.validateIdentifiers(); err != nil {
+		return nil, err
+	}
+
+	dialect := opts.getDialect()
+
+	query := fmt.Sprintf(`SELECT %s, %s, %s FROM %s`,
+		dialect.quote(opts.identifierColumn), dialect.quote(opts.sourceColumn), dialect.quote(opts.columnName), opts.qualifiedTable(),
+	)
+
+	conditions, params, err := buildWhereConditions(opts, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE (" + strings.Join(conditions, ") AND (") + ")"
+	}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", params)
+	}
+
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to query sluggable: %w", err)
+	}
+	defer rows.Close()
+
+	var all []SimulatedChange
+
+	for rows.Next() {
+		var idValue any
+
+		var sourceValue, slugValue sql.NullString
+		if err := rows.Scan(&idValue, &sourceValue, &slugValue); err != nil {
+			return nil, fmt.Errorf("[sluggable] failed to scan sluggable value: %w", err)
+		}
+
+		all = append(all, SimulatedChange{Identifier: identifierString(idValue), Value: sourceValue.String, Current: slugValue.String})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to iterate sluggable rows: %w", err)
+	}
+
+	return all, nil
+}
+
+// similarIn is the in-memory equivalent of the similar slugs query.
+func (o options) similarIn(rows []SimulatedChange, slug string) map[string]string {
+	simularList := make(map[string]string)
+
+	for _, row := range rows {
+		if row.Current == "" {
+			continue
+		}
+
+		current := o.foldCase(row.Current)
+
+		if current == o.foldCase(o.unsuffixed(slug)) ||
+			strings.HasPrefix(current, o.foldCase(slug+o.getSuffixSeparator())) && strings.HasSuffix(current, o.foldCase(o.extension)) {
+			simularList[row.Identifier] = row.Current
+		}
+	}
+
+	return simularList
+}
@@ -0,0 +1,75 @@
+package sluggable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSluggable_Simulate(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "title", "slug" FROM "articles" WHERE ("deleted_at" IS NULL) AND (status = $1)`).
+		WithArgs("published").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug"}).
+			AddRow(int64(1), "Hello World", "hello-world").
+			AddRow(int64(2), "Hello World", "hello-world-2").
+			AddRow(int64(3), "Go Tips", "go-tips").
+			AddRow(int64(4), "Draft", nil))
+
+	s := New(WithTableName("articles"), WithSourceColumn("title"))
+
+	got, err := s.Simulate(context.Background(), db, WithPreset(EmailLocalPart()), WithWhere("status = ?", "published"))
+	if err != nil {
+		t.Fatalf("Sluggable.Simulate() error = %v", err)
+	}
+
+	want := []SimulatedChange{
+		{Identifier: "1", Value: "Hello World", Current: "hello-world", Simulated: "hello.world"},
+		{Identifier: "2", Value: "Hello World", Current: "hello-world-2", Simulated: "hello.world.2"},
+		{Identifier: "3", Value: "Go Tips", Current: "go-tips", Simulated: "go.tips"},
+		{Identifier: "4", Value: "Draft", Current: "", Simulated: "draft"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Sluggable.Simulate() = %+v, want %+v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Sluggable.Simulate()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	mock.ExpectQuery(`SELECT "id", "title", "slug" FROM "articles" WHERE ("deleted_at" IS NULL)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "slug"}).AddRow(int64(1), "Hello World", nil))
+
+	var previews []bool
+
+	checker := WithAvailabilityChecker(func(ctx context.Context, _ string) (bool, error) {
+		previews = append(previews, IsPreview(ctx))
+
+		return true, nil
+	})
+
+	if _, err := s.Simulate(context.Background(), db, checker); err != nil {
+		t.Fatalf("Sluggable.Simulate() error = %v", err)
+	}
+
+	if len(previews) != 1 || !previews[0] {
+		t.Errorf("IsPreview() = %v, want the simulation to run as a preview", previews)
+	}
+
+	if _, err := s.Simulate(context.Background(), db, WithSourceColumn("")); err == nil {
+		t.Error("Sluggable.Simulate() should require a source column")
+	}
+}
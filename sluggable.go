@@ -1,15 +1,39 @@
 package sluggable
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxAvailabilityAttempts = 10
+	hashFallbackLength      = 12
+	defaultMaxInputLength   = 64 << 10 // Bytes, far beyond any title
+	likeEscape              = "!"      // Not special in string literals of any dialect, unlike a backslash
 )
 
 type Sluggable struct {
 	options options
 }
 
+// Result describes how a slug was generated. The JSON field names are stable, so HTTP handlers can
+// return it directly. The query is left out to not expose the schema.
+type Result struct {
+	Slug              string `json:"slug"`               // The final, unique slug
+	Base              string `json:"base"`               // The slug before any suffix was appended
+	Suffix            int    `json:"suffix"`             // The numeric suffix, 0 when none was appended or another suffix strategy is used
+	HadCollision      bool   `json:"collided"`           // Whether the base slug was already taken
+	Attempts          int    `json:"attempts"`           // Number of candidate slugs tried, 0 when the record keeps its slug
+	Truncated         bool   `json:"truncated"`          // Whether the base was shortened to fit the max length
+	CandidatesChecked int    `json:"candidates_checked"` // Number of similar slugs returned by the query
+	Query             string `json:"-"`                  // The query used to look up similar slugs
+}
+
 func New(options ...sluggableOption) *Sluggable {
 	opts := getDefaultOptions()
 	for _, option := range options {
@@ -19,83 +43,361 @@ func New(options ...sluggableOption) *Sluggable {
 	return &Sluggable{options: opts}
 }
 
-//nolint:cyclop,funlen
+// NewStrict is like New but returns an error when the options can't produce a valid query.
+func NewStrict(options ...sluggableOption) (*Sluggable, error) {
+	s := New(options...)
+	if err := s.options.validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
 func (s *Sluggable) Generate(db contextExecutor, value string, options ...sluggableOption) (string, error) {
-	opts := s.options // Important: copy instead of pointer reference
-	for _, option := range options {
-		option(&opts)
+	return s.GenerateContext(context.Background(), db, value, options...)
+}
+
+// GenerateContext is like Generate, the context is passed to the queries, availability checkers and
+// suffix strategies.
+func (s *Sluggable) GenerateContext(ctx context.Context, db contextExecutor, value string, options ...sluggableOption) (string, error) {
+	result, err := s.GenerateDetailedContext(ctx, db, value, options...)
+	if err != nil {
+		return "", err
 	}
 
-	if len(opts.tableName) == 0 {
-		return "", fmt.Errorf("[sluggable] table name cannot be empty")
+	return result.Slug, nil
+}
+
+func (s *Sluggable) GenerateDetailed(db contextExecutor, value string, options ...sluggableOption) (Result, error) {
+	return s.GenerateDetailedContext(context.Background(), db, value, options...)
+}
+
+func (s *Sluggable) GenerateDetailedContext(ctx context.Context, db contextExecutor, value string, options ...sluggableOption) (Result, error) {
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return Result{}, err
 	}
 
-	slug := opts.method(value, opts.separator)
+	return generateDetailed(ctx, db, opts, value)
+}
+
+// similarLookup returns the id → slug map of the slugs similar to the given slug, and the executed query.
+type similarLookup func(slug string) (map[string]string, string, error)
 
-	sql := `SELECT "id", "{column}" FROM "{table}" WHERE ("{column}" = $1 OR "{column}" LIKE $2)`
+// generateDetailed generates the unique slug for the value with already resolved options.
+func generateDetailed(ctx context.Context, db contextExecutor, opts options, value string) (Result, error) {
+	return generateAudited(ctx, opts, value, func(opts options) (Result, error) {
+		return generateUnique(ctx, db, opts, value)
+	})
+}
 
-	params := []any{slug, fmt.Sprint(slug, opts.separator, "%")}
+// generateAudited runs generate with the decision log and the suffix warning, previews skip both.
+func generateAudited(ctx context.Context, opts options, value string, generate func(options) (Result, error)) (Result, error) {
+	if opts.preview {
+		return generate(opts)
+	}
 
-	for whereSql, args := range opts.wheres {
-		normalizedSql := whereSql
+	if opts.decisionLog != nil {
+		opts.trace = &decisionTrace{}
+	}
 
-		for i := 0; i < len(args); i++ {
-			placeholder := fmt.Sprintf("$%d", len(params)+1)
-			// Replace only the first occurrence of "?" with the correct placeholder
-			normalizedSql = strings.Replace(normalizedSql, "?", placeholder, 1)
+	result, err := generate(opts)
+	if opts.decisionLog != nil {
+		opts.logDecision(ctx, value, result, err)
+	}
 
-			params = append(params, args[i])
+	if err == nil && opts.suffixWarning != nil && result.Suffix > opts.suffixWarningLimit {
+		opts.suffixWarning(ctx, result)
+	}
+
+	return result, err
+}
+
+func generateUnique(ctx context.Context, db contextExecutor, opts options, value string) (Result, error) {
+	opts, slug, err := opts.baseSlug(value)
+	if err != nil {
+		return Result{}, err
+	}
+
+	ctx = opts.previewContext(ctx)
+
+	restoreTimeout := func() error { return nil }
+
+	if opts.statementTimeout > 0 && !opts.sideEffectFree() && db != nil {
+		if restoreTimeout, err = setStatementTimeout(ctx, db, opts); err != nil {
+			return Result{}, err
+		}
+	}
+
+	if opts.advisoryLock && !opts.sideEffectFree() && db != nil {
+		if err := acquireAdvisoryLock(ctx, db, opts, slug); err != nil {
+			return Result{}, err
 		}
+	}
+
+	result, err := generateFitting(ctx, opts, slug, func(slug string) (map[string]string, string, error) {
+		return querySimilar(ctx, db, opts, slug)
+	})
 
-		sql += fmt.Sprintf(" AND (%s)", normalizedSql)
+	// After a failed lookup the Postgres transaction is aborted and restoring fails, keep the lookup error
+	if restoreErr := restoreTimeout(); err == nil {
+		err = restoreErr
 	}
 
-	sql = strings.ReplaceAll(sql, "{table}", opts.tableName)
-	sql = strings.ReplaceAll(sql, "{column}", opts.columnName)
+	if err == nil && opts.holds != nil && !opts.sideEffectFree() && !opts.deferHold {
+		opts.holds.hold(result.Slug, identifierString(opts.identifier))
+	}
+
+	return result, err
+}
 
-	if opts.debug {
-		fmt.Printf("[sluggable] %s\n", sql)
-		fmt.Printf("[sluggable] %v\n", params)
+// baseSlug returns the slug before any suffix, and the options with the per call value and extension set.
+func (o options) baseSlug(value string) (options, string, error) {
+	if err := o.checkInputLength(value); err != nil {
+		return o, "", err
 	}
 
-	rows, err := db.Query(sql, params...)
+	o.value = value
+	value, o.extension = o.splitExtension(value)
+
+	slug, err := o.makeSlug(value)
 	if err != nil {
-		return "", fmt.Errorf("[sluggable] failed to query sluggable: %w", err)
+		return o, "", err
+	}
+
+	truncated := o.truncate(slug, o.maxLength-utf8.RuneCountInString(o.extension))
+	o.truncated = truncated != slug
+
+	return o, truncated, nil
+}
+
+// checkInputLength returns ErrInputTooLong when the values together exceed the max input length.
+func (o options) checkInputLength(values ...string) error {
+	if o.maxInputLength <= 0 {
+		return nil
+	}
+
+	length := 0
+	for _, value := range values {
+		length += len(value)
 	}
-	defer rows.Close()
 
-	simularList := make(map[string]string)
+	if length > o.maxInputLength {
+		return fmt.Errorf("%w: %d bytes, the limit is %d", ErrInputTooLong, length, o.maxInputLength)
+	}
 
-	for rows.Next() {
-		var idValue string
+	return nil
+}
 
-		var slugValue string
-		if err := rows.Scan(&idValue, &slugValue); err != nil {
-			return "", fmt.Errorf("[sluggable] failed to scan sluggable value: %w", err)
+// generateFitting resolves the unique slug, shortening the base until the suffixed slug fits the max length.
+func generateFitting(ctx context.Context, opts options, slug string, lookup similarLookup) (Result, error) {
+	for {
+		result, err := generateFor(ctx, lookup, opts, slug)
+		if err != nil {
+			return result, err
 		}
 
-		simularList[idValue] = slugValue
+		if opts.maxLength <= 0 || utf8.RuneCountInString(result.Slug) <= opts.maxLength {
+			return result, opts.checkConstraint(result.Slug)
+		}
+
+		// The suffix doesn't fit, make room for it and look up the shorter base instead
+		shorter := opts.truncate(slug, opts.maxLength-(utf8.RuneCountInString(result.Slug)-utf8.RuneCountInString(slug)))
+		if shorter == "" || shorter == slug {
+			return Result{}, fmt.Errorf("[sluggable] no unique slug fits in %d characters", opts.maxLength)
+		}
+
+		slug = shorter
+		opts.truncated = true
 	}
+}
 
-	if len(simularList) == 0 {
-		return slug, nil
+// generateFor resolves the unique slug for an already slugified base.
+func generateFor(ctx context.Context, lookup similarLookup, opts options, slug string) (Result, error) {
+	simularList, query, err := lookup(slug)
+	if err != nil {
+		return Result{}, err
 	}
 
-	if opts.identifier != "" {
-		if existingSlug, exists := simularList[opts.identifier]; exists {
-			if existingSlug == slug || existingSlug == "" || strings.HasPrefix(existingSlug, slug) {
-				return existingSlug, nil
+	result := Result{
+		Slug:              opts.unsuffixed(slug),
+		Base:              opts.unsuffixed(slug),
+		Truncated:         opts.truncated,
+		CandidatesChecked: len(simularList),
+		Query:             query,
+	}
+
+	if identifier := identifierString(opts.identifier); identifier != "" {
+		if existingSlug, exists := simularList[identifier]; exists {
+			if existingSlug == "" || strings.HasPrefix(opts.foldCase(existingSlug), opts.foldCase(slug)) {
+				result.Slug = existingSlug
+				result.Suffix, _ = opts.parseSuffix(slug, existingSlug)
+
+				return result, nil
 			}
 		}
 	}
 
-	latestSuffix := 0
-
+	simulars := make([]string, 0, len(simularList))
 	for _, simular := range simularList {
-		suffix := strings.TrimPrefix(simular, fmt.Sprint(slug, opts.separator))
+		simulars = append(simulars, simular)
+	}
+
+	opts.trace.found(simulars)
+
+	for attempt := 0; ; attempt++ {
+		result.Attempts = attempt + 1
+
+		if len(simulars) > 0 {
+			result.HadCollision = true
+
+			result.Slug, result.Suffix, err = opts.nextCandidate(ctx, slug, simulars, attempt)
+			if err != nil {
+				return Result{}, err
+			}
+		}
 
-		suffixAsNumber, err := strconv.Atoi(suffix)
+		opts.trace.try(result.Slug)
+
+		available, err := opts.isAvailable(ctx, result.Slug)
 		if err != nil {
+			return Result{}, err
+		}
+
+		// Random and hash suffixes can hit a slug that's already taken
+		available = available && !opts.containsSlug(simulars, result.Slug)
+
+		if available {
+			return result, nil
+		}
+
+		if attempt >= maxAvailabilityAttempts {
+			return Result{}, fmt.Errorf("%w: no available slug for %q after %d attempts", ErrCollisionLimitExceeded, slug, attempt+1)
+		}
+
+		// Treat the unavailable slug like a taken one so the next suffix is tried
+		simulars = append(simulars, result.Slug)
+	}
+}
+
+// checkConstraint returns ErrConstraintViolation when the final slug doesn't match WithConstraint.
+func (o options) checkConstraint(slug string) error {
+	if o.constraint == nil || o.constraint.MatchString(slug) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q doesn't match %s", ErrConstraintViolation, slug, o.constraint)
+}
+
+func (o options) containsSlug(slugs []string, slug string) bool {
+	for _, candidate := range slugs {
+		if o.foldCase(candidate) == o.foldCase(slug) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// foldCase lowers the slug when slugs are compared case-insensitively.
+func (o options) foldCase(slug string) string {
+	if o.caseInsensitive {
+		return strings.ToLower(slug)
+	}
+
+	return slug
+}
+
+// isReserved reports whether the slug was given to WithReserved, ignoring case when slugs are compared
+// case-insensitively.
+func (o options) isReserved(slug string) bool {
+	if _, reserved := o.reserved[slug]; reserved || !o.caseInsensitive {
+		return reserved
+	}
+
+	for reserved := range o.reserved {
+		if o.foldCase(reserved) == o.foldCase(slug) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAvailable checks the slug against the reserved slugs, the holds and the availability checker.
+func (o options) isAvailable(ctx context.Context, slug string) (bool, error) {
+	if o.isReserved(slug) {
+		return false, nil
+	}
+
+	if o.holds != nil && o.holds.isHeld(slug, identifierString(o.identifier)) {
+		return false, nil
+	}
+
+	if o.availabilityChecker == nil {
+		return true, nil
+	}
+
+	available, err := o.availabilityChecker(ctx, slug)
+	if err != nil {
+		return false, fmt.Errorf("[sluggable] failed to check availability: %w", err)
+	}
+
+	return available, nil
+}
+
+// resolveOptions applies the per-call options on top of the instance options.
+func (s *Sluggable) resolveOptions(ctx context.Context, db contextExecutor, options []sluggableOption) (options, error) {
+	opts := s.options // Important: copy instead of pointer reference
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if err := validateRulesVersion(opts.rulesVersion); err != nil {
+		return opts, err
+	}
+
+	if err := validateSuffixFormat(opts.suffixFormat); err != nil {
+		return opts, err
+	}
+
+	opts, err := opts.resolveTenant(ctx)
+	if err != nil {
+		return opts, err
+	}
+
+	// Replicas reject locking reads
+	if opts.readOnly {
+		opts.lock = NoLock
+	}
+
+	// Without a database the availability checker is the only uniqueness check, e.g. against git branches
+	if db == nil {
+		if opts.availabilityChecker == nil {
+			return opts, fmt.Errorf("[sluggable] db can only be nil with an availability checker")
+		}
+
+		return opts, nil
+	}
+
+	if len(opts.tableName) == 0 {
+		return opts, fmt.Errorf("[sluggable] table name cannot be empty")
+	}
+
+	if opts.dialect.name == "" {
+		opts.dialect = detectDialect(db, opts.driverName)
+	}
+
+	return opts, nil
+}
+
+// nextSuffix returns the suffix following the highest numeric suffix among the similar slugs.
+func (o options) nextSuffix(slug string, simulars []string) int {
+	latestSuffix := 0
+
+	for _, simular := range simulars {
+		suffixAsNumber, ok := o.parseSuffix(slug, simular)
+		if !ok {
 			continue
 		}
 
@@ -105,16 +407,204 @@ func (s *Sluggable) Generate(db contextExecutor, value string, options ...slugga
 	}
 
 	if latestSuffix > 0 {
-		return fmt.Sprint(slug, opts.separator, latestSuffix+1), nil
+		return latestSuffix + 1
 	}
 
-	return fmt.Sprint(slug, opts.separator, opts.firstUniqueSuffix), nil
+	return o.firstUniqueSuffix
 }
 
-// func Generate(db contextExecutor, value string, options ...sluggableOption) (string, error) {
-// 	// if _global == nil {
-// 	// 	_global = New()
-// 	// }
+// parseSuffix returns the numeric suffix of a similar slug, e.g. 3 for "hello-world-3".
+func (o options) parseSuffix(slug, simular string) (int, bool) {
+	slug, simular = o.foldCase(slug), o.foldCase(simular)
 
-// 	// return _global.Generate(db, value, options...)
-// }
+	if !strings.HasPrefix(simular, fmt.Sprint(slug, o.getSuffixSeparator())) || !strings.HasSuffix(simular, o.extension) {
+		return 0, false
+	}
+
+	suffix := strings.TrimSuffix(strings.TrimPrefix(simular, fmt.Sprint(slug, o.getSuffixSeparator())), o.extension)
+
+	// Atoi accepts signs, "hello-world--2" is no suffixed variant of "hello-world"
+	if suffix == "" || strings.Trim(suffix, "0123456789") != "" {
+		return 0, false
+	}
+
+	suffixAsNumber, err := strconv.Atoi(suffix)
+	if err != nil {
+		return 0, false
+	}
+
+	return suffixAsNumber, true
+}
+
+func (o options) unsuffixed(slug string) string {
+	return slug + o.extension
+}
+
+func (o options) suffixed(slug string, suffix int) string {
+	if o.suffixFormat != "" {
+		return fmt.Sprint(slug, o.getSuffixSeparator(), fmt.Sprintf(o.suffixFormat, suffix), o.extension)
+	}
+
+	return fmt.Sprint(slug, o.getSuffixSeparator(), suffix, o.extension)
+}
+
+// validateSuffixFormat makes sure the format renders a number that parseSuffix can read back.
+func validateSuffixFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+
+	// parseSuffix only reads digits, so signs ("%+d") and padding spaces can't be read back
+	sample := fmt.Sprintf(format, 12)
+	if rendered, err := strconv.Atoi(sample); err != nil || rendered != 12 || strings.Trim(sample, "0123456789") != "" {
+		return fmt.Errorf("[sluggable] suffix format %q must render the number only, like %%03d", format)
+	}
+
+	return nil
+}
+
+// likePattern matches every suffixed variant of the slug.
+func (o options) likePattern(slug string) string {
+	return fmt.Sprint(o.escapeLike(slug), o.escapeLike(o.getSuffixSeparator()), "%", o.escapeLike(o.extension))
+}
+
+// escapeLike escapes the LIKE wildcards of the dialect and the escape character itself, so slugs from
+// custom methods like "100%_off" don't match unrelated rows.
+func (o options) escapeLike(value string) string {
+	special := o.getDialect().likeWildcards + likeEscape
+	if !strings.ContainsAny(value, special) {
+		return value
+	}
+
+	var escaped strings.Builder
+
+	for _, char := range value {
+		if strings.ContainsRune(special, char) {
+			escaped.WriteString(likeEscape)
+		}
+
+		escaped.WriteRune(char)
+	}
+
+	return escaped.String()
+}
+
+// makeSlug turns a value into the base slug without blocked words, falling back to the untitled base
+// for empty results and to the empty fallback for empty or too short results. Numeric-only slugs get
+// their prefix and suffix when forbidden.
+func (o options) makeSlug(value string) (string, error) {
+	slug := value
+	if !o.slugified {
+		slug = o.slugify(value)
+	}
+
+	slug, err := o.blockWords(slug)
+	if err != nil {
+		return "", err
+	}
+
+	if slug == "" && o.untitled != "" {
+		slug = o.slugify(o.untitled)
+	}
+
+	if o.forbidNumericOnly && slug != "" && isNumeric(slug) {
+		// Slugified like the value, so "Year " or "№" can't produce an invalid slug
+		affixed := strings.Join(nonEmpty(o.slugify(o.numericPrefix), slug, o.slugify(o.numericSuffix)), o.separator)
+		if isNumeric(affixed) {
+			return "", fmt.Errorf("%w: %q", ErrNumericOnlySlug, slug)
+		}
+
+		slug = affixed
+	}
+
+	if slug != "" && utf8.RuneCountInString(slug) >= o.minLength {
+		return slug, nil
+	}
+
+	switch o.emptyFallback {
+	case FallbackUUID:
+		return newUUID()
+	case FallbackHash:
+		sum := sha256.Sum256([]byte(value))
+
+		return hex.EncodeToString(sum[:])[:hashFallbackLength], nil
+	case FallbackError:
+		return "", fmt.Errorf("%w: %q gives %q, shorter than %d characters", ErrSlugTooShort, value, slug, o.minLength)
+	default:
+		return slug, nil
+	}
+}
+
+// truncate shortens the slug to at most length characters when a max length is configured,
+// without leaving a trailing separator. Words are kept whole when truncating on word boundaries,
+// unless the first word alone is too long.
+func (o options) truncate(slug string, length int) string {
+	if o.maxLength <= 0 || utf8.RuneCountInString(slug) <= length {
+		return slug
+	}
+
+	if length <= 0 {
+		return ""
+	}
+
+	runes := []rune(slug)
+	truncated := string(runes[:length])
+
+	if o.truncateOnWords && o.separator != "" && !strings.HasPrefix(string(runes[length:]), o.separator) {
+		if i := strings.LastIndex(truncated, o.separator); i > 0 {
+			truncated = truncated[:i]
+		}
+	}
+
+	for o.separator != "" && strings.HasSuffix(truncated, o.separator) {
+		truncated = strings.TrimSuffix(truncated, o.separator)
+	}
+
+	return truncated
+}
+
+func nonEmpty(values ...string) []string {
+	kept := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+
+	return kept
+}
+
+func (o options) slugify(value string) string {
+	value = o.substitute(value)
+
+	if o.transliterator != nil {
+		value = o.transliterator(value)
+	}
+
+	if o.foldConfusables {
+		value = foldConfusables(value)
+	}
+
+	return o.applyMethod(value)
+}
+
+// isNumeric reports whether the slug only consists of digits.
+func isNumeric(slug string) bool {
+	return strings.Trim(slug, "0123456789") == ""
+}
+
+// Generate generates a unique slug using the global configuration, see Configure.
+func Generate(db contextExecutor, value string, options ...sluggableOption) (string, error) {
+	return getGlobal().Generate(db, value, options...)
+}
+
+// GenerateFrom generates a unique slug from several fields using the global configuration, see Configure.
+func GenerateFrom(db contextExecutor, values []string, options ...sluggableOption) (string, error) {
+	return getGlobal().GenerateFrom(db, values, options...)
+}
+
+// GenerateContext is like Generate with a context.
+func GenerateContext(ctx context.Context, db contextExecutor, value string, options ...sluggableOption) (string, error) {
+	return getGlobal().GenerateContext(ctx, db, value, options...)
+}
@@ -1,8 +1,15 @@
 package sluggable
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -119,8 +126,8 @@ func TestSluggable_Generate(t *testing.T) {
 			options: []sluggableOption{WithTableName("articles"), WithSeparator("_")},
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "slug"})
-				mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE \("slug" = \$1 OR "slug" LIKE \$2\)`).
-					WithArgs("hello-world", "hello-world_%").
+				mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE \("slug" = \$1 OR "slug" LIKE \$2 ESCAPE '!'\)`).
+					WithArgs("hello-world", "hello-world!_%").
 					WillReturnRows(rows)
 			},
 			want:    "hello-world",
@@ -203,62 +210,115 @@ func TestSluggable_Generate(t *testing.T) {
 	}
 }
 
-//nolint:godox
-// TODO: fix bug in global
-// func TestGenerate_GlobalFunction(t *testing.T) {
-// 	// Test the global Generate function
-// 	db, mock, err := sqlmock.New()
-// 	if err != nil {
-// 		t.Fatalf("Failed to create mock database: %v", err)
-// 	}
-// 	defer db.Close()
+func TestGenerate_GlobalFunction(t *testing.T) {
+	ResetGlobal()
+	defer ResetGlobal()
+
+	// Test the global Generate function
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "slug"})
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE \("slug" = \$1 OR "slug" LIKE \$2\)`).
+		WithArgs("test-article", "test-article-%").
+		WillReturnRows(rows)
+
+	got, err := Generate(db, "Test Article", WithTableName("articles"))
+	if err != nil {
+		t.Errorf("Generate() error = %v", err)
+
+		return
+	}
+
+	want := "test-article"
+	if got != want {
+		t.Errorf("Generate() = %v, want %v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	// Reset global state
+	ResetGlobal()
+	defer ResetGlobal()
+
+	Configure(WithSeparator("_"), WithFirstUniqueSuffix(1))
+
+	if _global == nil {
+		t.Fatal("Configure() should initialize global instance")
+	}
+
+	if _global.options.separator != "_" {
+		t.Errorf("Configure() separator = %v, want _", _global.options.separator)
+	}
+
+	if _global.options.firstUniqueSuffix != 1 {
+		t.Errorf("Configure() firstUniqueSuffix = %v, want 1", _global.options.firstUniqueSuffix)
+	}
+
+	// Test reconfiguring existing global
+	Configure(WithSeparator("-"))
+	if _global.options.separator != "-" {
+		t.Errorf("Configure() reconfigure separator = %v, want -", _global.options.separator)
+	}
+
+	if _global.options.firstUniqueSuffix != 1 {
+		t.Errorf("Configure() should keep earlier options, firstUniqueSuffix = %v, want 1", _global.options.firstUniqueSuffix)
+	}
+}
+
+func TestConfigure_Concurrent(t *testing.T) {
+	ResetGlobal()
+	defer ResetGlobal()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
 
-// 	rows := sqlmock.NewRows([]string{"id", "slug"})
-// 	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE \("slug" = \$1 OR "slug" LIKE \$2\)`).
-// 		WithArgs("test-article", "test-article-%").
-// 		WillReturnRows(rows)
+	mock.MatchExpectationsInOrder(false)
 
-// 	got, err := Generate(db, "Test Article", WithTableName("articles"))
-// 	if err != nil {
-// 		t.Errorf("Generate() error = %v", err)
+	const workers = 10
 
-// 		return
-// 	}
+	for i := 0; i < workers; i++ {
+		mock.ExpectQuery(`SELECT`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+	}
 
-// 	want := "test-article"
-// 	if got != want {
-// 		t.Errorf("Generate() = %v, want %v", got, want)
-// 	}
+	var wg sync.WaitGroup
 
-// 	if err := mock.ExpectationsWereMet(); err != nil {
-// 		t.Errorf("There were unfulfilled expectations: %s", err)
-// 	}
-// }
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
 
-// func TestConfigure(t *testing.T) {
-// 	// Reset global state
-// 	_global = nil
+		go func() {
+			defer wg.Done()
 
-// 	Configure(WithSeparator("_"), WithFirstUniqueSuffix(1))
+			Configure(WithFirstUniqueSuffix(3))
+		}()
 
-// 	if _global == nil {
-// 		t.Fatal("Configure() should initialize global instance")
-// 	}
+		go func() {
+			defer wg.Done()
 
-// 	if _global.options.separator != "_" {
-// 		t.Errorf("Configure() separator = %v, want _", _global.options.separator)
-// 	}
+			if _, err := Generate(db, "Hello World", WithTableName("articles")); err != nil {
+				t.Errorf("Generate() error = %v", err)
+			}
+		}()
+	}
 
-// 	if _global.options.firstUniqueSuffix != 1 {
-// 		t.Errorf("Configure() firstUniqueSuffix = %v, want 1", _global.options.firstUniqueSuffix)
-// 	}
+	wg.Wait()
 
-// 	// Test reconfiguring existing global
-// 	Configure(WithSeparator("-"))
-// 	if _global.options.separator != "-" {
-// 		t.Errorf("Configure() reconfigure separator = %v, want -", _global.options.separator)
-// 	}
-// }
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
 
 //nolint:funlen
 func TestOptions(t *testing.T) {
@@ -336,7 +396,7 @@ func TestWithWhere_BasicFunctionality(t *testing.T) {
 		t.Errorf("Expected 2 where clauses, got %d", len(s.options.wheres))
 	}
 
-	params, exists := s.options.wheres["user_id = ?"]
+	params, exists := findWhere(s.options.wheres, "user_id = ?")
 	if !exists {
 		t.Error("Custom WHERE clause not found")
 
@@ -355,29 +415,20 @@ func TestSluggable_GenerateWithScanError(t *testing.T) {
 	}
 	defer db.Close()
 
-	// Create a row with incompatible types to trigger a scan error
-	// When we try to scan into string variables but provide incompatible data
-	rows := sqlmock.NewRows([]string{"id", "slug"}).
-		AddRow(nil, "test") // nil in ID column should cause scan error
+	// Return more columns than there are destinations to trigger a scan error,
+	// NULL identifiers and slugs are scanned without errors
+	rows := sqlmock.NewRows([]string{"id", "slug", "title"}).
+		AddRow("1", "test", "Test")
 
 	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE \("slug" = \$1 OR "slug" LIKE \$2\)`).
 		WithArgs("test", "test-%").
 		WillReturnRows(rows)
 
 	s := New()
-	_, err = s.Generate(db, "test", WithTableName("articles"))
-
-	// Note: This test might not always produce a scan error depending on the driver
-	// The main goal is to test the error path, so we check if we get any error
-	// or specifically a scan error if it occurs
-	if err != nil && strings.Contains(err.Error(), "failed to scan") {
-		// This is the expected scan error
-		return
-	}
 
-	// If no scan error occurred (which is also valid), just ensure the function works
-	if err != nil {
-		t.Logf("Got error (not scan error): %v", err)
+	_, err = s.Generate(db, "test", WithTableName("articles"))
+	if err == nil || !strings.Contains(err.Error(), "failed to scan") {
+		t.Errorf("Sluggable.Generate() error = %v, want scan error", err)
 	}
 }
 
@@ -465,7 +516,7 @@ func TestWithDeleted_NewBehavior(t *testing.T) {
 			}
 
 			for expectedSQL, expectedParams := range tt.expectedWheres {
-				actualParams, exists := s.options.wheres[expectedSQL]
+				actualParams, exists := findWhere(s.options.wheres, expectedSQL)
 				if !exists {
 					t.Errorf("Expected WHERE clause '%s' not found", expectedSQL)
 
@@ -517,7 +568,7 @@ func TestWithWhere_Functionality(t *testing.T) {
 				t.Errorf("Expected %d where clauses, got %d", expectedCount, len(s.options.wheres))
 			}
 
-			actualParams, exists := s.options.wheres[tt.whereSQL]
+			actualParams, exists := findWhere(s.options.wheres, tt.whereSQL)
 			if !exists {
 				t.Errorf("Custom WHERE clause '%s' not found", tt.whereSQL)
 
@@ -599,9 +650,58 @@ func TestWithDeleted_Integration(t *testing.T) {
 	}
 }
 
+func TestWithSoftDeleteColumn(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		want    string
+		args    []driver.Value
+	}{
+		{
+			name:    "timestamp column",
+			options: []sluggableOption{WithSoftDeleteColumn("removed_at")},
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("removed_at" IS NULL)`,
+			args:    []driver.Value{"test-article", "test-article-%"},
+		},
+		{
+			name:    "boolean column",
+			options: []sluggableOption{WithSoftDeleteBoolean("is_deleted", false), WithWhere(`"status" = ?`, "published")},
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("is_deleted" = $3) AND ("status" = $4)`,
+			args:    []driver.Value{"test-article", "test-article-%", false, "published"},
+		},
+		{
+			name:    "included with WithDeleted",
+			options: []sluggableOption{WithSoftDeleteBoolean("is_deleted", false), WithDeleted()},
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2)`,
+			args:    []driver.Value{"test-article", "test-article-%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(tt.want).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+			if _, err := New(tt.options...).Generate(db, "Test Article", WithTableName("articles")); err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestCombinedWithDeletedAndWithWhere(t *testing.T) {
 	// This test validates the logical combination works (unit test level)
-	// We'll avoid the integration test due to the parameter duplication bug
 
 	s := New(
 		WithDeleted(),                   // Include soft deleted records
@@ -618,7 +718,7 @@ func TestCombinedWithDeletedAndWithWhere(t *testing.T) {
 	}
 
 	for expectedSQL, expectedParams := range expectedWheres {
-		actualParams, exists := s.options.wheres[expectedSQL]
+		actualParams, exists := findWhere(s.options.wheres, expectedSQL)
 		if !exists {
 			t.Errorf("Expected WHERE clause '%s' not found", expectedSQL)
 
@@ -638,8 +738,8 @@ func TestCombinedWithDeletedAndWithWhere(t *testing.T) {
 }
 
 func TestMultipleWithWhere(t *testing.T) {
-	// This test validates multiple WHERE clauses work at the unit test level
-	// We'll avoid the integration test due to the parameter duplication bug
+	// This test validates multiple WHERE clauses work at the unit test level,
+	// see TestWithWhere_MultipleIntegration for the generated query
 
 	s := New(
 		WithWhere(`"user_id" = ?`, 789),
@@ -660,7 +760,7 @@ func TestMultipleWithWhere(t *testing.T) {
 	}
 
 	for expectedSQL, expectedParams := range expectedWheres {
-		actualParams, exists := s.options.wheres[expectedSQL]
+		actualParams, exists := findWhere(s.options.wheres, expectedSQL)
 		if !exists {
 			t.Errorf("Expected WHERE clause '%s' not found", expectedSQL)
 
@@ -678,3 +778,1544 @@ func TestMultipleWithWhere(t *testing.T) {
 		}
 	}
 }
+
+func TestSluggable_GenerateDetailed(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   []sluggableOption
+		mockSetup func(sqlmock.Sqlmock)
+		want      Result
+	}{
+		{
+			name:    "no collision",
+			options: []sluggableOption{WithTableName("articles")},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+					WithArgs("hello-world", "hello-world-%").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+			},
+			want: Result{Slug: "hello-world", Base: "hello-world", Attempts: 1},
+		},
+		{
+			name:    "truncated",
+			options: []sluggableOption{WithTableName("articles"), WithMaxLength(5)},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+					WithArgs("hello", "hello-%").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+			},
+			want: Result{Slug: "hello", Base: "hello", Attempts: 1, Truncated: true},
+		},
+		{
+			name:    "collision with existing suffixes",
+			options: []sluggableOption{WithTableName("articles")},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "slug"}).
+					AddRow("1", "hello-world").
+					AddRow("2", "hello-world-2")
+				mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+					WithArgs("hello-world", "hello-world-%").
+					WillReturnRows(rows)
+			},
+			want: Result{Slug: "hello-world-3", Base: "hello-world", Suffix: 3, HadCollision: true, Attempts: 1, CandidatesChecked: 2},
+		},
+		{
+			name:    "identifier keeps its own slug",
+			options: []sluggableOption{WithTableName("articles"), WithIdentifier("2")},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "slug"}).
+					AddRow("1", "hello-world").
+					AddRow("2", "hello-world-2")
+				mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+					WithArgs("hello-world", "hello-world-%").
+					WillReturnRows(rows)
+			},
+			want: Result{Slug: "hello-world-2", Base: "hello-world", Suffix: 2, CandidatesChecked: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			got, err := New().GenerateDetailed(db, "hello world", tt.options...)
+			if err != nil {
+				t.Fatalf("Sluggable.GenerateDetailed() error = %v", err)
+			}
+
+			if got.Query == "" {
+				t.Error("Sluggable.GenerateDetailed() should report the executed query")
+			}
+
+			got.Query = ""
+			if got != tt.want {
+				t.Errorf("Sluggable.GenerateDetailed() = %+v, want %+v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestResult_MarshalJSON(t *testing.T) {
+	result := Result{
+		Slug:              "hello-world-3",
+		Base:              "hello-world",
+		Suffix:            3,
+		HadCollision:      true,
+		Attempts:          1,
+		CandidatesChecked: 2,
+		Query:             `SELECT "id", "slug" FROM "articles"`,
+	}
+
+	got, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"slug":"hello-world-3","base":"hello-world","suffix":3,"collided":true,"attempts":1,"truncated":false,"candidates_checked":2}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestWithIdentifierColumn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"article_id", "slug"}).
+		AddRow("a1", "hello-world").
+		AddRow("a2", "hello-world-2")
+	mock.ExpectQuery(`SELECT "article_id", "slug" FROM "articles" WHERE \("slug" = \$1 OR "slug" LIKE \$2\)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(rows)
+
+	s := New(WithIdentifierColumn("article_id"))
+
+	got, err := s.Generate(db, "hello world", WithTableName("articles"), WithIdentifier("a2"))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world-2" {
+		t.Errorf("Sluggable.Generate() = %v, want hello-world-2", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithConfusableFolding(t *testing.T) {
+	identity := WithMethod(func(value, separator string) string {
+		return strings.ToLower(value)
+	})
+
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    string
+	}{
+		{
+			name:    "cyrillic lookalikes are folded",
+			options: []sluggableOption{identity, WithConfusableFolding()},
+			value:   "pаypаl", // Cyrillic "а"
+			want:    "paypal",
+		},
+		{
+			name:    "greek lookalikes are folded",
+			options: []sluggableOption{identity, WithConfusableFolding()},
+			value:   "ΤΟΚΥΟ",
+			want:    "tokyo",
+		},
+		{
+			name:    "dotless i is folded",
+			options: []sluggableOption{identity, WithConfusableFolding()},
+			value:   "lınkedın",
+			want:    "linkedin",
+		},
+		{
+			name:    "folding is disabled by default",
+			options: []sluggableOption{identity},
+			value:   "pаypаl",
+			want:    "pаypаl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.options...)
+			if got, _ := s.options.makeSlug(tt.value); got != tt.want {
+				t.Errorf("makeSlug(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func findWhere(wheres []whereClause, sql string) ([]any, bool) {
+	for _, where := range wheres {
+		if where.SQL == sql {
+			return where.Args, true
+		}
+	}
+
+	return nil, false
+}
+
+func TestWithWhere_DeterministicOrder(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	s := New(
+		WithWhere(`"user_id" = ?`, 1),
+		WithWhere(`"status" = ?`, "published"),
+		WithWhere(`"category_id" = ?`, 3),
+	)
+
+	query := `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) ` +
+		`AND ("user_id" = $3) AND ("status" = $4) AND ("category_id" = $5)`
+
+	for i := 0; i < 10; i++ {
+		mock.ExpectQuery(query).
+			WithArgs("hello-world", "hello-world-%", 1, "published", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+		if _, err := s.Generate(db, "Hello World", WithTableName("articles")); err != nil {
+			t.Fatalf("Sluggable.Generate() error = %v", err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithWhere_PerCallDoesNotLeak(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+		WithArgs("hello-world", "hello-world-%", 1, "draft").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	s := New(WithWhere(`"user_id" = ?`, 1))
+	if _, err := s.Generate(db, "Hello World", WithTableName("articles"), WithDeleted(), WithWhere(`"status" = ?`, "draft")); err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if len(s.options.wheres) != 2 {
+		t.Errorf("Per-call options should not change the instance, got %d where clauses", len(s.options.wheres))
+	}
+}
+
+func TestBindPlaceholders(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		clause  string
+		args    []any
+		offset  int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "single placeholder",
+			dialect: Postgres,
+			clause:  `"user_id" = ?`,
+			args:    []any{1},
+			offset:  2,
+			want:    `"user_id" = $3`,
+		},
+		{
+			name:    "multiple placeholders",
+			dialect: Postgres,
+			clause:  `"user_id" = ? AND "status" IN (?, ?)`,
+			args:    []any{1, "draft", "published"},
+			offset:  3,
+			want:    `"user_id" = $4 AND "status" IN ($5, $6)`,
+		},
+		{
+			name:    "question marks inside literals are kept",
+			dialect: Postgres,
+			clause:  `"title" <> 'why?' AND "user_id" = ?`,
+			args:    []any{1},
+			offset:  2,
+			want:    `"title" <> 'why?' AND "user_id" = $3`,
+		},
+		{
+			name:    "mysql keeps question marks",
+			dialect: MySQL,
+			clause:  "`user_id` = ? AND `status` = ?",
+			args:    []any{1, "draft"},
+			offset:  2,
+			want:    "`user_id` = ? AND `status` = ?",
+		},
+		{
+			name:    "escaped question marks are operators",
+			dialect: Postgres,
+			clause:  `"tags" ?? ? AND "meta" ??| ? AND "meta" ??& ?`,
+			args:    []any{"featured", "{a,b}", "{c}"},
+			offset:  2,
+			want:    `"tags" ? $3 AND "meta" ?| $4 AND "meta" ?& $5`,
+		},
+		{
+			name:    "escaped question marks on mysql",
+			dialect: MySQL,
+			clause:  "`note` = 'x' AND `flag` ?? ?",
+			args:    []any{1},
+			want:    "`note` = 'x' AND `flag` ? ?",
+		},
+		{
+			name:    "no placeholders",
+			dialect: Postgres,
+			clause:  `"published" = TRUE`,
+			want:    `"published" = TRUE`,
+		},
+		{
+			name:    "more params than placeholders",
+			dialect: Postgres,
+			clause:  `"user_id" = ?`,
+			args:    []any{1, 2},
+			wantErr: true,
+		},
+		{
+			name:    "more placeholders than params",
+			dialect: Postgres,
+			clause:  `"user_id" = ? AND "status" = ?`,
+			args:    []any{1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bindPlaceholders(tt.dialect, tt.clause, tt.args, tt.offset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("bindPlaceholders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("bindPlaceholders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithWhere_MultipleIntegration(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) `+
+		`AND ("user_id" = $3 AND "team_id" = $4) AND ("published" = TRUE) AND ("status" IN ($5, $6))`).
+		WithArgs("hello-world", "hello-world-%", 1, 2, "draft", "published").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	s := New(
+		WithWhere(`"user_id" = ? AND "team_id" = ?`, 1, 2),
+		WithWhere(`"published" = TRUE`),
+		WithWhere(`"status" IN (?, ?)`, "draft", "published"),
+	)
+	if _, err := s.Generate(db, "Hello World", WithTableName("articles")); err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithWhere_PlaceholderMismatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	s := New(WithWhere(`"user_id" = ? AND "team_id" = ?`, 1))
+
+	_, err = s.Generate(db, "Hello World", WithTableName("articles"))
+	if err == nil || !strings.Contains(err.Error(), "2 placeholders but 1 params") {
+		t.Errorf("Sluggable.Generate() error = %v, want placeholder mismatch", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIdentifierValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+	}{
+		{name: "quote in table name", options: []sluggableOption{WithTableName(`articles" --`)}},
+		{name: "semicolon in table name", options: []sluggableOption{WithTableName("articles;DROP TABLE users")}},
+		{name: "whitespace in column name", options: []sluggableOption{WithTableName("articles"), WithColumnName("slug OR 1=1")}},
+		{name: "dot in identifier column", options: []sluggableOption{WithTableName("articles"), WithIdentifierColumn("users.id")}},
+		{name: "backtick in schema", options: []sluggableOption{WithTableName("articles"), WithSchema("cms`")}},
+		{name: "empty column name", options: []sluggableOption{WithTableName("articles"), WithColumnName("")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			_, err = New().Generate(db, "Hello World", tt.options...)
+			if !errors.Is(err, ErrInvalidIdentifier) {
+				t.Errorf("Sluggable.Generate() error = %v, want %v", err, ErrInvalidIdentifier)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRightToLeftScripts(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "arabic", value: "مرحبا بالعالم", want: "mrhb-bl-lm"},
+		{name: "hebrew", value: "שלום עולם", want: "shlvm-vlm"},
+		{name: "mixed with latin and digits", value: "Hello שלום 2", want: "hello-shlvm-2"},
+		{name: "bidi control marks are dropped", value: "‏שלום‎ עולם", want: "shlvm-vlm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			// The suffix is appended at the logical end of the transliterated slug
+			rows := sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", tt.want)
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+				WithArgs(tt.want, tt.want+"-%").
+				WillReturnRows(rows)
+
+			got, err := New().Generate(db, tt.value, WithTableName("articles"))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want+"-2" {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want+"-2")
+			}
+		})
+	}
+}
+
+func TestWithSuffixSeparator(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+		WithArgs("hello_world", "hello!_world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "hello_world").AddRow("2", "hello_world-2"))
+
+	s := New(WithMethod(emailLocalPartMethod), WithSeparator("_"), WithSuffixSeparator("-"))
+
+	got, err := s.Generate(db, "Hello World", WithTableName("articles"))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello_world-3" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello_world-3")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithUntitled(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		untitled string
+		existing []string
+		want     string
+	}{
+		{name: "empty value", value: "", untitled: "untitled", want: "untitled"},
+		{name: "value without slug characters", value: "!!!", untitled: "untitled", want: "untitled"},
+		{name: "localized base is slugified", value: "", untitled: "Sin título", want: "sin-titulo"},
+		{name: "suffixes like any other slug", value: "", untitled: "untitled", existing: []string{"untitled", "untitled-2"}, want: "untitled-3"},
+		{name: "regular values are untouched", value: "Hello World", untitled: "untitled", want: "hello-world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			base := strings.TrimSuffix(tt.want, "-3")
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+
+			for i, existing := range tt.existing {
+				rows.AddRow(fmt.Sprint(i+1), existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+				WithArgs(base, base+"-%").
+				WillReturnRows(rows)
+
+			got, err := New(WithUntitled(tt.untitled)).Generate(db, tt.value, WithTableName("articles"))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStrict(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     []sluggableOption
+		errContains string
+	}{
+		{name: "valid options", options: []sluggableOption{WithWhere(`"user_id" = ?`, 1)}},
+		{name: "empty separator", options: []sluggableOption{WithSeparator("")}, errContains: "separator cannot be empty"},
+		{name: "negative first suffix", options: []sluggableOption{WithFirstUniqueSuffix(-1)}, errContains: "cannot be negative"},
+		{name: "nil method", options: []sluggableOption{WithMethod(nil)}, errContains: "method cannot be nil"},
+		{name: "invalid column", options: []sluggableOption{WithColumnName("slug;")}, errContains: "invalid identifier"},
+		{name: "placeholder mismatch", options: []sluggableOption{WithWhere(`"user_id" = ?`)}, errContains: "1 placeholders but 0 params"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStrict(tt.options...)
+			if tt.errContains == "" {
+				if err != nil || s == nil {
+					t.Errorf("NewStrict() = %v, %v, want instance", s, err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("NewStrict() error = %v, want error containing %v", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestSluggable_Options(t *testing.T) {
+	s := New(
+		WithSeparator("_"),
+		WithSchema("cms"),
+		WithTableName("articles"),
+		WithDialect(MySQL),
+		WithWhere("`user_id` = ?", 1),
+		WithConflictRetry(5),
+	)
+
+	got := s.Options()
+
+	if got.MaxLength != 0 || got.ConflictRetry != 5 || got.AvailabilityChecker {
+		t.Errorf("Options() = %+v, want configured limits", got)
+	}
+
+	if got.Separator != "_" || got.Schema != "cms" || got.TableName != "articles" || got.ColumnName != "slug" {
+		t.Errorf("Options() = %+v, want configured separator, schema, table and column", got)
+	}
+
+	if got.Dialect != "mysql" || got.IdentifierColumn != "id" || got.FirstUniqueSuffix != 2 {
+		t.Errorf("Options() = %+v, want mysql dialect and defaults", got)
+	}
+
+	if len(got.Wheres) != 2 || got.Wheres[0].SQL != excludeDeletedWhere || got.Wheres[1].Args[0] != 1 {
+		t.Errorf("Options() wheres = %+v, want soft delete exclusion and custom where", got.Wheres)
+	}
+
+	// Mutating the snapshot must not change the instance
+	got.Wheres[1].Args[0] = 2
+	got.Wheres = nil
+
+	if s.options.wheres[1].Args[0] != 1 {
+		t.Error("Options() should return a copy of the where clauses")
+	}
+
+	s = New(
+		WithSubstitutions(map[string]string{"&": "and"}),
+		WithRedirects(map[string]string{"old": "new"}),
+		WithFreezeWindows(FreezeWindow{Reason: "Black Friday"}),
+		WithBlockedWords("darn"),
+	)
+
+	got = s.Options()
+	got.Substitutions["&"] = "n"
+	got.Redirects["old"] = "other"
+	got.FreezeWindows[0].Reason = "Cyber Monday"
+	got.BlockedWords[0][0] = "heck"
+
+	if s.options.substitutions["&"] != "and" || s.options.redirects["old"] != "new" ||
+		s.options.freezeWindows[0].Reason != "Black Friday" || s.options.blockedWords[0][0] != "darn" {
+		t.Error("Options() should return copies of the substitutions, redirects, freeze windows and blocked words")
+	}
+}
+
+func TestCompareOptions(t *testing.T) {
+	base := New(WithPreset(KubernetesName()), WithTableName("clusters"))
+
+	if got := CompareOptions(base, New(WithPreset(KubernetesName()), WithTableName("clusters"), WithDebug(true))); len(got) != 0 {
+		t.Errorf("CompareOptions() = %v, want no differences", got)
+	}
+
+	got := CompareOptions(base, New(WithPreset(Hostname()), WithTableName("clusters"), WithSeparator("_"), WithWhere("active = ?", true)))
+
+	fields := make([]string, len(got))
+	for i, difference := range got {
+		fields[i] = difference.Field
+	}
+
+	if want := "Separator,Reserved,Wheres"; strings.Join(fields, ",") != want {
+		t.Errorf("CompareOptions() fields = %v, want %v", fields, want)
+	}
+
+	if got[0].String() != "Separator: - -> _" {
+		t.Errorf("Difference.String() = %v, want %v", got[0].String(), "Separator: - -> _")
+	}
+
+	got = CompareOptions(New(), New(WithMethod(rfc1123Method)))
+	if len(got) != 1 || got[0].Field != "Method" || !strings.HasSuffix(got[0].B, "rfc1123Method") {
+		t.Errorf("CompareOptions() = %v, want a method difference", got)
+	}
+}
+
+func TestSluggable_GenerateScanning(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     []sluggableOption
+		rows        func() *sqlmock.Rows
+		want        string
+		errContains string
+	}{
+		{
+			name: "null slugs are skipped",
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", nil)
+			},
+			want: "hello-world",
+		},
+		{
+			name:    "integer identifiers",
+			options: []sluggableOption{WithIdentifier("5")},
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "slug"}).AddRow(int64(4), "hello-world").AddRow(int64(5), "hello-world-2")
+			},
+			want: "hello-world-2",
+		},
+		{
+			name:    "byte identifiers",
+			options: []sluggableOption{WithIdentifier("a0eebc99")},
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "slug"}).AddRow([]byte("a0eebc99"), "hello-world")
+			},
+			want: "hello-world",
+		},
+		{
+			name: "iteration errors are returned",
+			rows: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"id", "slug"}).
+					AddRow("1", "hello-world").
+					AddRow("2", "hello-world-2").
+					RowError(1, fmt.Errorf("connection reset"))
+			},
+			errContains: "failed to iterate sluggable rows",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+				WithArgs("hello-world", "hello-world-%").
+				WillReturnRows(tt.rows())
+
+			got, err := New().Generate(db, "Hello World", append(tt.options, WithTableName("articles"))...)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("Sluggable.Generate() error = %v, want error containing %v", err, tt.errContains)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testUUID [2]byte
+
+func (u testUUID) String() string {
+	return fmt.Sprintf("%x-%x", u[0], u[1])
+}
+
+func TestWithIdentifier_KeyTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier any
+		id         any
+	}{
+		{name: "int identifier, int64 column", identifier: 5, id: int64(5)},
+		{name: "int64 identifier, string column", identifier: int64(5), id: "5"},
+		{name: "string identifier, byte column", identifier: "5", id: []byte("5")},
+		{name: "stringer identifier, byte column", identifier: testUUID{0xab, 0xcd}, id: []byte("ab-cd")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"}).
+				AddRow("other", "hello-world").
+				AddRow(tt.id, "hello-world-2")
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+				WithArgs("hello-world", "hello-world-%").
+				WillReturnRows(rows)
+
+			got, err := New().Generate(db, "Hello World", WithTableName("articles"), WithIdentifier(tt.identifier))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != "hello-world-2" {
+				t.Errorf("Sluggable.Generate() = %v, want hello-world-2", got)
+			}
+		})
+	}
+}
+
+func TestSluggable_GenerateAndSave(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		want    string
+	}{
+		{
+			name: "postgres",
+			want: `UPDATE "articles" SET "slug" = $1 WHERE "id" = $2`,
+		},
+		{
+			name:    "mysql with schema",
+			options: []sluggableOption{WithDialect(MySQL), WithSchema("cms")},
+			want:    "UPDATE `cms`.`articles` SET `slug` = ? WHERE `id` = ?",
+		},
+		{
+			name:    "custom columns",
+			options: []sluggableOption{WithColumnName("handle"), WithIdentifierColumn("uuid")},
+			want:    `UPDATE "articles" SET "handle" = $1 WHERE "uuid" = $2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "hello-world"))
+			mock.ExpectExec(regexp.QuoteMeta(tt.want)).
+				WithArgs("hello-world-2", 42).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			options := append(tt.options, WithTableName("articles"), WithIdentifier(42))
+
+			got, err := New().GenerateAndSave(context.Background(), db, "Hello World", options...)
+			if err != nil {
+				t.Fatalf("Sluggable.GenerateAndSave() error = %v", err)
+			}
+
+			if got != "hello-world-2" {
+				t.Errorf("Sluggable.GenerateAndSave() = %v, want %v", got, "hello-world-2")
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	if _, err := New().GenerateAndSave(context.Background(), nil, "Hello World", WithTableName("articles")); err == nil {
+		t.Error("Sluggable.GenerateAndSave() should require a db")
+	}
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := New().GenerateAndSave(context.Background(), db, "Hello World", WithTableName("articles")); err == nil {
+		t.Error("Sluggable.GenerateAndSave() should require an identifier")
+	}
+}
+
+func TestWithApproval(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  any
+		approved bool
+		wantAsk  bool
+		wantErr  error
+	}{
+		{name: "approved", current: "old-title", approved: true, wantAsk: true},
+		{name: "rejected", current: "old-title", wantAsk: true, wantErr: ErrChangeNotApproved},
+		{name: "no slug yet", current: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+			mock.ExpectQuery(`SELECT "slug" FROM "articles" WHERE "id" = $1`).
+				WithArgs(42).
+				WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow(tt.current))
+
+			if tt.wantErr == nil {
+				mock.ExpectExec(`UPDATE "articles" SET "slug" = $1 WHERE "id" = $2`).
+					WithArgs("new-title", 42).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			var asked []string
+
+			approve := func(old, new string) (bool, error) {
+				asked = append(asked, old, new)
+
+				return tt.approved, nil
+			}
+
+			_, err = New().GenerateAndSave(context.Background(), db, "New Title",
+				WithTableName("articles"), WithIdentifier(42), WithApproval(approve))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sluggable.GenerateAndSave() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if gotAsk := len(asked) > 0; gotAsk != tt.wantAsk || (gotAsk && strings.Join(asked, ",") != "old-title,new-title") {
+				t.Errorf("Sluggable.GenerateAndSave() asked %v, want asked %v", asked, tt.wantAsk)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWithReserved(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		existing []string
+		want     Result
+	}{
+		{name: "free", value: "About", want: Result{Slug: "about", Base: "about", Attempts: 1}},
+		{name: "reserved", value: "Admin", want: Result{Slug: "admin-2", Base: "admin", Suffix: 2, HadCollision: true, Attempts: 2}},
+		{
+			name:     "reserved and taken suffixes",
+			value:    "New",
+			existing: []string{"new-2"},
+			want:     Result{Slug: "new-3", Base: "new", Suffix: 3, HadCollision: true, Attempts: 1, CandidatesChecked: 1},
+		},
+	}
+
+	s := New(WithTableName("pages"), WithReserved("admin", "api"), WithReserved("new"))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for i, existing := range tt.existing {
+				rows.AddRow(fmt.Sprint(i+1), existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "pages"`).WillReturnRows(rows)
+
+			got, err := s.GenerateDetailed(db, tt.value)
+			if err != nil {
+				t.Fatalf("Sluggable.GenerateDetailed() error = %v", err)
+			}
+
+			got.Query = ""
+			if got != tt.want {
+				t.Errorf("Sluggable.GenerateDetailed() = %+v, want %+v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWithReservedCaseInsensitive(t *testing.T) {
+	available := WithAvailabilityChecker(func(context.Context, string) (bool, error) { return true, nil })
+
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		want    string
+	}{
+		{name: "case-sensitive", options: []sluggableOption{WithReserved("Admin")}, want: "admin"},
+		{name: "case-insensitive", options: []sluggableOption{WithReserved("Admin"), WithCaseInsensitive()}, want: "admin-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(append(tt.options, available)...).Generate(nil, "admin")
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := New(WithReserved("Admin"), WithCaseInsensitive()).Validate("admin"); !errors.Is(err, ErrInvalidSlug) {
+		t.Errorf("Sluggable.Validate() error = %v, want %v", err, ErrInvalidSlug)
+	}
+}
+
+func TestWithReservedFromTable(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	s := New(WithTableName("articles"), WithReservedFromTable("redirects", "path"))
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "hello-world-2"))
+	mock.ExpectQuery(`SELECT "path" FROM "redirects" WHERE ("path" = $1 OR "path" LIKE $2)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"path"}).AddRow("hello-world").AddRow("hello-world-3"))
+
+	got, err := s.Generate(db, "Hello World")
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world-4" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-4")
+	}
+
+	mock.ExpectQuery(`SELECT "id" FROM "articles" WHERE ("slug" = $1) AND ("deleted_at" IS NULL)`).
+		WithArgs("hello-world").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT "path" FROM "redirects" WHERE "path" = $1`).
+		WithArgs("hello-world").
+		WillReturnRows(sqlmock.NewRows([]string{"path"}).AddRow("hello-world"))
+
+	available, err := s.IsAvailable(db, "hello-world")
+	if err != nil {
+		t.Fatalf("Sluggable.IsAvailable() error = %v", err)
+	}
+
+	if available {
+		t.Errorf("Sluggable.IsAvailable() = %v, want %v", available, false)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "hello-world-2"))
+	mock.ExpectQuery(`SELECT "path" FROM "redirects" WHERE ("path" = $1 OR "path" LIKE $2)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"path"}).AddRow("hello-world"))
+
+	similar, err := s.FindSimilar(db, "Hello World")
+	if err != nil {
+		t.Fatalf("Sluggable.FindSimilar() error = %v", err)
+	}
+
+	if len(similar) != 1 || similar["1"] != "hello-world-2" {
+		t.Errorf("Sluggable.FindSimilar() = %q, want only the record 1", similar)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	if _, err := NewStrict(WithReservedFromTable("redirects", "path; DROP")); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("NewStrict() error = %v, want %v", err, ErrInvalidIdentifier)
+	}
+}
+
+func TestSluggable_IsAvailable(t *testing.T) {
+	tests := []struct {
+		name    string
+		slug    string
+		options []sluggableOption
+		query   string
+		args    []driver.Value
+		rows    *sqlmock.Rows
+		want    bool
+	}{
+		{
+			name:  "free slug",
+			slug:  "hello-world",
+			query: `SELECT "id" FROM "articles" WHERE ("slug" = $1) AND ("deleted_at" IS NULL)`,
+			args:  []driver.Value{"hello-world"},
+			rows:  sqlmock.NewRows([]string{"id"}),
+			want:  true,
+		},
+		{
+			name:  "taken slug",
+			slug:  "hello-world",
+			query: `SELECT "id" FROM "articles" WHERE ("slug" = $1) AND ("deleted_at" IS NULL)`,
+			args:  []driver.Value{"hello-world"},
+			rows:  sqlmock.NewRows([]string{"id"}).AddRow("1"),
+			want:  false,
+		},
+		{
+			name:    "scoped and excluding the record itself",
+			slug:    "hello-world",
+			options: []sluggableOption{WithWhere("tenant_id = ?", 7), WithIdentifier(3)},
+			query:   `SELECT "id" FROM "articles" WHERE ("slug" = $1) AND ("deleted_at" IS NULL) AND (tenant_id = $2) AND ("id" <> $3)`,
+			args:    []driver.Value{"hello-world", int64(7), int64(3)},
+			rows:    sqlmock.NewRows([]string{"id"}),
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).WillReturnRows(tt.rows)
+
+			got, err := New(WithTableName("articles")).IsAvailable(db, tt.slug, tt.options...)
+			if err != nil {
+				t.Fatalf("Sluggable.IsAvailable() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.IsAvailable() = %v, want %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	got, err := New(WithReserved("admin")).IsAvailable(nil, "admin", WithAvailabilityChecker(func(context.Context, string) (bool, error) {
+		return true, nil
+	}))
+	if err != nil || got {
+		t.Errorf("Sluggable.IsAvailable() = %v, %v, want reserved slugs to be unavailable", got, err)
+	}
+}
+
+func TestSluggable_Resolve(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	query := `SELECT "id" FROM "articles" WHERE ("slug" = $1) AND ("deleted_at" IS NULL) AND (tenant_id = $2)`
+
+	mock.ExpectQuery(query).
+		WithArgs("hello-world", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(42)))
+	mock.ExpectQuery(query).
+		WithArgs("missing", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	s := New(WithTableName("articles"), WithWhere("tenant_id = ?", 7))
+
+	got, err := s.Resolve(db, "hello-world", WithIdentifier(42))
+	if err != nil {
+		t.Fatalf("Sluggable.Resolve() error = %v", err)
+	}
+
+	if got != "42" {
+		t.Errorf("Sluggable.Resolve() = %v, want %v", got, "42")
+	}
+
+	if _, err := s.Resolve(db, "missing"); !errors.Is(err, ErrSlugNotFound) {
+		t.Errorf("Sluggable.Resolve() error = %v, want %v", err, ErrSlugNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSluggable_SimilarWhere(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    []sluggableOption
+		wantWhere  string
+		wantParams []any
+	}{
+		{
+			name:       "defaults",
+			wantWhere:  `("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`,
+			wantParams: []any{"hello-world", "hello-world-%"},
+		},
+		{
+			name:       "param start index",
+			options:    []sluggableOption{WithParamStartIndex(3), WithWhere("tenant_id = ?", 7)},
+			wantWhere:  `("slug" = $3 OR "slug" LIKE $4) AND ("deleted_at" IS NULL) AND (tenant_id = $5)`,
+			wantParams: []any{"hello-world", "hello-world-%", 7},
+		},
+		{
+			name:       "dialect from driver name",
+			options:    []sluggableOption{WithDriverName("sqlserver"), WithParamStartIndex(2), WithDeleted()},
+			wantWhere:  `([slug] = @p2 OR [slug] LIKE @p3)`,
+			wantParams: []any{"hello-world", "hello-world-%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, params, err := New().SimilarWhere("hello-world", tt.options...)
+			if err != nil {
+				t.Fatalf("Sluggable.SimilarWhere() error = %v", err)
+			}
+
+			if where != tt.wantWhere {
+				t.Errorf("Sluggable.SimilarWhere() where = %v, want %v", where, tt.wantWhere)
+			}
+
+			if fmt.Sprint(params) != fmt.Sprint(tt.wantParams) {
+				t.Errorf("Sluggable.SimilarWhere() params = %v, want %v", params, tt.wantParams)
+			}
+		})
+	}
+
+	if _, _, err := New().SimilarWhere("hello-world", WithParamStartIndex(0)); err == nil {
+		t.Error("Sluggable.SimilarWhere() should reject a start index below 1")
+	}
+}
+
+func TestSluggable_CollisionPredicate(t *testing.T) {
+	s := New(WithDialect(MySQL), WithColumnName("handle"), WithWhere("tenant_id = ?", 7))
+
+	predicate, params, err := s.CollisionPredicate("hello-world")
+	if err != nil {
+		t.Fatalf("Sluggable.CollisionPredicate() error = %v", err)
+	}
+
+	if want := "(`handle` = ? OR `handle` LIKE ?)"; predicate != want {
+		t.Errorf("Sluggable.CollisionPredicate() = %v, want %v", predicate, want)
+	}
+
+	if fmt.Sprint(params) != "[hello-world hello-world-%]" {
+		t.Errorf("Sluggable.CollisionPredicate() params = %v, want %v", params, "[hello-world hello-world-%]")
+	}
+
+	if _, _, err := s.CollisionPredicate("hello-world", WithColumnName(`slug"; --`)); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("Sluggable.CollisionPredicate() error = %v, want %v", err, ErrInvalidIdentifier)
+	}
+}
+
+func TestSluggable_FindSimilar(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow(int64(1), "hello-world").AddRow(int64(4), "hello-world-2"))
+
+	got, err := New(WithTableName("articles")).FindSimilar(db, "Hello World")
+	if err != nil {
+		t.Fatalf("Sluggable.FindSimilar() error = %v", err)
+	}
+
+	if len(got) != 2 || got["1"] != "hello-world" || got["4"] != "hello-world-2" {
+		t.Errorf("Sluggable.FindSimilar() = %v, want both records", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithCaseInsensitive(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles" WHERE (LOWER("slug") = $1 OR LOWER("slug") LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("hello-world", "hello-world-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "Hello-World").AddRow("2", "HELLO-WORLD-2"))
+	mock.ExpectQuery(`SELECT "id" FROM "articles" WHERE (LOWER("slug") = $1) AND ("deleted_at" IS NULL)`).
+		WithArgs("hello-world").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+
+	s := New(WithTableName("articles"), WithCaseInsensitive())
+
+	got, err := s.Generate(db, "Hello World")
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world-3" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-3")
+	}
+
+	available, err := s.IsAvailable(db, "Hello-World")
+	if err != nil || available {
+		t.Errorf("Sluggable.IsAvailable() = %v, %v, want taken", available, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithRowDecoder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).
+			AddRow("1", `{"en":"hello-world"}`).
+			AddRow("2", `{"en":"hello-world-2"}`).
+			AddRow("3", `{"de":"hallo-welt"}`))
+
+	decoder := func(rows *sql.Rows) (string, string, error) {
+		var id, raw string
+		if err := rows.Scan(&id, &raw); err != nil {
+			return "", "", err
+		}
+
+		var localized map[string]string
+		if err := json.Unmarshal([]byte(raw), &localized); err != nil {
+			return "", "", err
+		}
+
+		return "tenant-a/" + id, localized["en"], nil
+	}
+
+	got, err := New(WithTableName("articles"), WithRowDecoder(decoder)).Generate(db, "Hello World")
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "hello-world-3" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-3")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithMaxLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []sluggableOption
+		existing []string
+		want     string
+	}{
+		{name: "short values are untouched", options: []sluggableOption{WithMaxLength(50)}, want: "the-quick-brown-fox"},
+		{name: "cut mid word", options: []sluggableOption{WithMaxLength(12)}, want: "the-quick-br"},
+		{name: "cut on word boundary", options: []sluggableOption{WithMaxLength(12), WithTruncateOnWordBoundary()}, want: "the-quick"},
+		{name: "cut exactly at a separator", options: []sluggableOption{WithMaxLength(9), WithTruncateOnWordBoundary()}, want: "the-quick"},
+		{
+			name:     "room for the suffix",
+			options:  []sluggableOption{WithMaxLength(12), WithTruncateOnWordBoundary()},
+			existing: []string{"the-quick"},
+			want:     "the-quick-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for i, existing := range tt.existing {
+				rows.AddRow(fmt.Sprint(i+1), existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).WillReturnRows(rows)
+
+			got, err := New(tt.options...).Generate(db, "The Quick Brown Fox", WithTableName("articles"))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSluggable_GenerateContext(t *testing.T) {
+	type tenantKey struct{}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "articles"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "hello-world"))
+
+	var checked []string
+
+	s := New(
+		WithTableName("articles"),
+		WithAvailabilityChecker(func(ctx context.Context, slug string) (bool, error) {
+			checked = append(checked, fmt.Sprint(ctx.Value(tenantKey{}), ":", slug))
+
+			return true, nil
+		}),
+		WithSuffixFuncContext(func(ctx context.Context, base string, attempt int) (string, error) {
+			return fmt.Sprint(ctx.Value(tenantKey{})), nil
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	got, err := s.GenerateContext(ctx, db, "Hello World")
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateContext() error = %v", err)
+	}
+
+	if got != "hello-world-acme" || strings.Join(checked, ",") != "acme:hello-world-acme" {
+		t.Errorf("Sluggable.GenerateContext() = %v after checking %v, want the context passed everywhere", got, checked)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := s.GenerateContext(cancelled, db, "Hello World"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sluggable.GenerateContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWithEmptyFallback(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	hashPattern := regexp.MustCompile(`^[0-9a-f]{12}$`)
+
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    *regexp.Regexp
+		wantErr error
+	}{
+		{name: "empty slugs are kept by default", value: "🎉🎉", want: regexp.MustCompile(`^$`)},
+		{name: "uuid", options: []sluggableOption{WithEmptyFallback(FallbackUUID)}, value: "🎉🎉", want: uuidPattern},
+		{name: "hash", options: []sluggableOption{WithEmptyFallback(FallbackHash)}, value: "🎉🎉", want: hashPattern},
+		{name: "error", options: []sluggableOption{WithEmptyFallback(FallbackError)}, value: "🎉🎉", wantErr: ErrSlugTooShort},
+		{
+			name:    "untitled is tried first",
+			options: []sluggableOption{WithUntitled("untitled"), WithEmptyFallback(FallbackError)},
+			value:   "🎉🎉",
+			want:    regexp.MustCompile(`^untitled$`),
+		},
+		{
+			name:    "too short",
+			options: []sluggableOption{WithMinLength(3), WithEmptyFallback(FallbackHash)},
+			value:   "A",
+			want:    hashPattern,
+		},
+		{
+			name:    "long enough",
+			options: []sluggableOption{WithMinLength(3), WithEmptyFallback(FallbackError)},
+			value:   "Abc",
+			want:    regexp.MustCompile(`^abc$`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.options...).options.makeSlug(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("makeSlug(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+
+			if tt.want != nil && !tt.want.MatchString(got) {
+				t.Errorf("makeSlug(%q) = %q, want match for %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	first, _ := New(WithEmptyFallback(FallbackHash)).options.makeSlug("🎉🎉")
+	second, _ := New(WithEmptyFallback(FallbackHash)).options.makeSlug("🎉🎉")
+
+	if first != second {
+		t.Errorf("makeSlug() = %q and %q, want the hash fallback to be stable", first, second)
+	}
+}
+
+func TestWithForbidNumericOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    string
+		wantErr error
+	}{
+		{name: "allowed by default", value: "2024", want: "2024"},
+		{name: "error", options: []sluggableOption{WithForbidNumericOnly("", "")}, value: "2024", wantErr: ErrNumericOnlySlug},
+		{name: "prefix", options: []sluggableOption{WithForbidNumericOnly("year", "")}, value: "2024!", want: "year-2024"},
+		{name: "suffix", options: []sluggableOption{WithForbidNumericOnly("", "edition")}, value: "42", want: "42-edition"},
+		{name: "separator", options: []sluggableOption{WithPreset(EmailLocalPart()), WithForbidNumericOnly("n", "")}, value: "1234", want: "n.1234"},
+		{name: "mixed values are kept", options: []sluggableOption{WithForbidNumericOnly("", "")}, value: "2024 Review", want: "2024-review"},
+		{name: "digits with separators are kept", options: []sluggableOption{WithForbidNumericOnly("", "")}, value: "2024-01", want: "2024-01"},
+		{name: "prefix is slugified", options: []sluggableOption{WithForbidNumericOnly("Year ", "")}, value: "2024", want: "year-2024"},
+		{name: "prefix without slug characters", options: []sluggableOption{WithForbidNumericOnly("№", "")}, value: "2024", wantErr: ErrNumericOnlySlug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.options...).options.makeSlug(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("makeSlug(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("makeSlug(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithConstraint(t *testing.T) {
+	threeDigits := regexp.MustCompile(`^[a-z-]+(-\d{3})?$`)
+
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		taken   []string
+		want    string
+		wantErr error
+	}{
+		{name: "free slug matches", options: []sluggableOption{WithConstraint(threeDigits)}, want: "hello-world"},
+		{
+			name:    "suffixed slug matches",
+			options: []sluggableOption{WithConstraint(threeDigits), WithSuffixFormat("%03d")},
+			taken:   []string{"hello-world"},
+			want:    "hello-world-002",
+		},
+		{name: "suffixed slug violates", options: []sluggableOption{WithConstraint(threeDigits)}, taken: []string{"hello-world"}, wantErr: ErrConstraintViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := func(ctx context.Context, slug string) (bool, error) {
+				for _, taken := range tt.taken {
+					if slug == taken {
+						return false, nil
+					}
+				}
+
+				return true, nil
+			}
+
+			got, err := New(append(tt.options, WithAvailabilityChecker(checker))...).Generate(nil, "Hello World")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sluggable.Generate() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package sluggable
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// OptionsSnapshot is a read-only copy of the effective configuration of a Sluggable.
+type OptionsSnapshot struct {
+	Debug       bool
+	DecisionLog bool // Whether a decision log is set
+	Presets     []string
+
+	RulesVersion      int // 0 when the slug method isn't pinned
+	Lang              string
+	Separator         string
+	SuffixSeparator   string // Empty when the separator is used
+	ConfusableFolding bool
+	Untitled          string
+	Substitutions     map[string]string
+	Transliterator    bool // Whether a transliterator is set
+	BlockedWords      [][]string
+	BlockedWordAction BlockedWordAction
+	ForbidNumericOnly bool
+	NumericPrefix     string
+	NumericSuffix     string
+	MinLength         int
+	EmptyFallback     EmptyFallback
+	PreserveExtension bool
+	MaxLength         int
+	TruncateOnWords   bool
+	MaxInputLength    int
+	Constraint        string // Pattern of WithConstraint, empty when not set
+
+	Schema          string
+	TableName       string
+	ColumnName      string
+	CaseInsensitive bool
+	PendingTable    string
+	SourceColumn    string
+
+	Identifier       any
+	IdentifierColumn string
+	ParentColumn     string
+	RowDecoder       bool // Whether a row decoder is set
+	QueryBuilder     bool // Whether a query builder is set
+	MaxCandidateRows int
+
+	FirstUniqueSuffix   int
+	SuffixStrategy      string // e.g. "numeric" or "random(8)"
+	SuffixFormat        string
+	MaxCollisionSuffix  int
+	SuffixWarningLimit  int
+	CollisionFallback   string
+	Reserved            []string // Sorted
+	ReservedTables      []string // "table.column"
+	AvailabilityChecker bool     // Whether an availability checker is set
+	Holds               bool     // Whether generated slugs are held, see WithHolds
+
+	Wheres          []WhereSnapshot
+	Scopes          map[string]any // Column → value
+	ParamStartIndex int
+
+	SoftDeleteColumn  string
+	SoftDeleteBoolean bool
+
+	TenantColumn   string
+	TenantResolver bool // Whether a tenant resolver is set
+
+	Dialect    string // Empty when the dialect is detected from the driver
+	DriverName string
+	Lock       LockMode
+
+	AdvisoryLock     bool
+	StatementTimeout time.Duration
+	Preview          bool
+	ReadOnly         bool
+
+	ConflictRetry int
+	Approval      bool // Whether an approval callback is set
+	FreezeWindows []FreezeWindow
+	Redirects     map[string]string
+	FaultInjector bool // Whether a fault injector is set
+}
+
+// WhereSnapshot is a copy of a WHERE clause added with WithWhere or by default.
+type WhereSnapshot struct {
+	SQL  string
+	Args []any
+}
+
+// Options returns a snapshot of the configuration, changing it doesn't affect the Sluggable.
+func (s *Sluggable) Options() OptionsSnapshot {
+	return s.options.snapshot()
+}
+
+func (o options) snapshot() OptionsSnapshot {
+	wheres := make([]WhereSnapshot, len(o.wheres))
+	for i, where := range o.wheres {
+		wheres[i] = WhereSnapshot{SQL: where.SQL, Args: append([]any(nil), where.Args...)}
+	}
+
+	scopes := make(map[string]any, len(o.scopes))
+	for _, scope := range o.scopes {
+		scopes[scope.column] = scope.value
+	}
+
+	constraint := ""
+	if o.constraint != nil {
+		constraint = o.constraint.String()
+	}
+
+	reserved := make([]string, 0, len(o.reserved))
+	for slug := range o.reserved {
+		reserved = append(reserved, slug)
+	}
+
+	sort.Strings(reserved)
+
+	reservedTables := make([]string, len(o.reservedTables))
+	for i, table := range o.reservedTables {
+		reservedTables[i] = table.table + "." + table.column
+	}
+
+	// Copies, so changing the snapshot doesn't change the configuration
+	var blockedWords [][]string
+	for _, words := range o.blockedWords {
+		blockedWords = append(blockedWords, append([]string(nil), words...))
+	}
+
+	return OptionsSnapshot{
+		Debug:               o.debug,
+		DecisionLog:         o.decisionLog != nil,
+		Presets:             append([]string(nil), o.presets...),
+		RulesVersion:        o.rulesVersion,
+		Lang:                o.lang,
+		Separator:           o.separator,
+		SuffixSeparator:     o.suffixSeparator,
+		ConfusableFolding:   o.foldConfusables,
+		Untitled:            o.untitled,
+		Substitutions:       copyStringMap(o.substitutions),
+		Transliterator:      o.transliterator != nil,
+		BlockedWords:        blockedWords,
+		BlockedWordAction:   o.blockedWordAction,
+		ForbidNumericOnly:   o.forbidNumericOnly,
+		NumericPrefix:       o.numericPrefix,
+		NumericSuffix:       o.numericSuffix,
+		MinLength:           o.minLength,
+		EmptyFallback:       o.emptyFallback,
+		PreserveExtension:   o.preserveExtension,
+		MaxLength:           o.maxLength,
+		TruncateOnWords:     o.truncateOnWords,
+		MaxInputLength:      o.maxInputLength,
+		Constraint:          constraint,
+		Schema:              o.schema,
+		TableName:           o.tableName,
+		ColumnName:          o.columnName,
+		CaseInsensitive:     o.caseInsensitive,
+		PendingTable:        o.pendingTable,
+		SourceColumn:        o.sourceColumn,
+		Identifier:          o.identifier,
+		IdentifierColumn:    o.identifierColumn,
+		ParentColumn:        o.parentColumn,
+		RowDecoder:          o.rowDecoder != nil,
+		QueryBuilder:        o.queryBuilder != nil,
+		MaxCandidateRows:    o.maxCandidateRows,
+		FirstUniqueSuffix:   o.firstUniqueSuffix,
+		SuffixStrategy:      o.suffixStrategy.String(),
+		SuffixFormat:        o.suffixFormat,
+		MaxCollisionSuffix:  o.maxCollisionSuffix,
+		CollisionFallback:   o.collisionFallback.String(),
+		SuffixWarningLimit:  o.suffixWarningLimit,
+		Reserved:            reserved,
+		ReservedTables:      reservedTables,
+		AvailabilityChecker: o.availabilityChecker != nil,
+		Holds:               o.holds != nil,
+		Wheres:              wheres,
+		Scopes:              scopes,
+		SoftDeleteColumn:    o.softDeleteColumn,
+		SoftDeleteBoolean:   o.softDeleteBoolean,
+		TenantColumn:        o.tenantColumn,
+		TenantResolver:      o.tenantResolver != nil,
+		ParamStartIndex:     o.paramStartIndex,
+		Dialect:             o.dialect.String(),
+		DriverName:          o.driverName,
+		Lock:                o.lock,
+		AdvisoryLock:        o.advisoryLock,
+		StatementTimeout:    o.statementTimeout,
+		Preview:             o.preview,
+		ReadOnly:            o.readOnly,
+		ConflictRetry:       o.conflictRetry,
+		Approval:            o.approval != nil,
+		FreezeWindows:       append([]FreezeWindow(nil), o.freezeWindows...),
+		Redirects:           copyStringMap(o.redirects),
+		FaultInjector:       o.faultInjector != nil,
+	}
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	copied := make(map[string]string, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+// Difference is a setting that differs between two configurations.
+type Difference struct {
+	Field string // Name of the OptionsSnapshot field, or "Method"
+	A     string
+	B     string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Field, d.A, d.B)
+}
+
+// CompareOptions reports the settings of a and b that can change the generated slugs or queries,
+// e.g. to review an upgraded preset before rolling it out. Debug output and preset names are ignored.
+// Slug methods are compared by function, so two different closures of the same function look equal.
+func CompareOptions(a, b *Sluggable) []Difference {
+	var differences []Difference
+
+	if methodA, methodB := methodName(a.options.method), methodName(b.options.method); methodA != methodB {
+		differences = append(differences, Difference{Field: "Method", A: methodA, B: methodB})
+	}
+
+	snapshotA := reflect.ValueOf(a.Options())
+	snapshotB := reflect.ValueOf(b.Options())
+
+	for i := 0; i < snapshotA.NumField(); i++ {
+		field := snapshotA.Type().Field(i).Name
+		if field == "Debug" || field == "Presets" {
+			continue
+		}
+
+		valueA, valueB := snapshotA.Field(i).Interface(), snapshotB.Field(i).Interface()
+		if !reflect.DeepEqual(valueA, valueB) {
+			differences = append(differences, Difference{Field: field, A: fmt.Sprintf("%v", valueA), B: fmt.Sprintf("%v", valueB)})
+		}
+	}
+
+	return differences
+}
+
+func methodName(method func(value, separator string) string) string {
+	if method == nil {
+		return "<nil>"
+	}
+
+	return runtime.FuncForPC(reflect.ValueOf(method).Pointer()).Name()
+}
@@ -3,6 +3,11 @@ package sluggable
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
 )
 
 type executor interface {
@@ -19,3 +24,867 @@ type contextExecutor interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
+
+// GenerateInTx generates a slug inside the transaction and locks the similar rows (FOR UPDATE by default).
+// Only existing rows are locked: when no row matches, e.g. for the first record with a title, two
+// transactions can still pick the same slug. Add WithAdvisoryLock on Postgres, or rely on a unique index
+// and GenerateWith. Pass WithLock(LockForShare) for a shared lock instead. Transactions don't expose their
+// driver, so set WithDialect or WithDriverName when not using Postgres.
+func (s *Sluggable) GenerateInTx(tx *sql.Tx, value string, options ...sluggableOption) (string, error) {
+	return s.GenerateInTxContext(context.Background(), tx, value, options...)
+}
+
+// GenerateInTxContext is like GenerateInTx with a context.
+func (s *Sluggable) GenerateInTxContext(ctx context.Context, tx *sql.Tx, value string, options ...sluggableOption) (string, error) {
+	return s.GenerateContext(ctx, tx, value, append([]sluggableOption{WithLock(LockForUpdate)}, options...)...)
+}
+
+// GenerateAndSave generates the slug for the record given with WithIdentifier and stores it with
+// UPDATE table SET slug = ? WHERE id = ?. Pass a *sql.Tx to generate and save inside a transaction.
+func (s *Sluggable) GenerateAndSave(ctx context.Context, db contextExecutor, value string, options ...sluggableOption) (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("[sluggable] db cannot be nil when saving")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return "", err
+	}
+
+	if err := opts.checkWritable("saving"); err != nil {
+		return "", err
+	}
+
+	if identifierString(opts.identifier) == "" {
+		return "", fmt.Errorf("[sluggable] saving requires an identifier")
+	}
+
+	result, err := generateDetailed(ctx, db, opts, value)
+	if err != nil {
+		return "", err
+	}
+
+	if len(opts.freezeWindows) > 0 || opts.approval != nil {
+		current, err := queryCurrentSlug(ctx, db, opts)
+		if err != nil {
+			return "", err
+		}
+
+		// Records without a slug yet and unchanged slugs don't change a live URL
+		if current != "" && current != result.Slug {
+			if err := opts.checkFrozen(time.Now()); err != nil {
+				return "", err
+			}
+
+			if err := opts.approveChange(current, result.Slug); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	query, err := buildUpdateQuery(opts)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", []any{result.Slug, opts.identifier})
+	}
+
+	if err := opts.injectFault(ctx, FaultSave); err != nil {
+		return "", fmt.Errorf("[sluggable] failed to save slug: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, query, result.Slug, opts.identifier); err != nil {
+		return "", fmt.Errorf("[sluggable] failed to save slug: %w", err)
+	}
+
+	return result.Slug, nil
+}
+
+// IsAvailable reports whether the slug can be used as is, e.g. to validate a slug chosen in a form.
+// Only rows with exactly this slug are looked up, scoped by the where clauses and excluding the record
+// given with WithIdentifier. Reserved slugs and the availability checker are honored as well.
+func (s *Sluggable) IsAvailable(db contextExecutor, slug string, options ...sluggableOption) (bool, error) {
+	return s.IsAvailableContext(context.Background(), db, slug, options...)
+}
+
+func (s *Sluggable) IsAvailableContext(ctx context.Context, db contextExecutor, slug string, options ...sluggableOption) (bool, error) {
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return false, err
+	}
+
+	if db != nil {
+		_, exists, err := lookupSlug(ctx, db, opts, slug)
+		if err != nil || exists {
+			return false, err
+		}
+
+		for _, table := range opts.reservedTables {
+			reserved, err := queryReservedTable(ctx, db, opts, table, slug, true)
+			if err != nil || len(reserved) > 0 {
+				return false, err
+			}
+		}
+	}
+
+	return opts.isAvailable(ctx, slug)
+}
+
+// Resolve returns the identifier of the record with the slug, scoped by the where clauses like Generate.
+// ErrSlugNotFound is returned when no record has the slug. With WithParentColumn the slug is a path
+// ("docs/getting-started/install") and the identifier of its last segment is returned.
+func (s *Sluggable) Resolve(db contextExecutor, slug string, options ...sluggableOption) (string, error) {
+	return s.ResolveContext(context.Background(), db, slug, options...)
+}
+
+func (s *Sluggable) ResolveContext(ctx context.Context, db contextExecutor, slug string, options ...sluggableOption) (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("[sluggable] db cannot be nil when resolving")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return "", err
+	}
+
+	// Resolving looks up any record, the identifier only excludes the record being updated
+	opts.identifier = nil
+
+	if opts.parentColumn != "" {
+		segments := splitPath(slug)
+
+		ids, err := resolvePath(ctx, db, opts, segments)
+		if err != nil {
+			return "", err
+		}
+
+		if len(segments) == 0 || len(ids) < len(segments) {
+			return "", fmt.Errorf("%w: %q", ErrSlugNotFound, slug)
+		}
+
+		return ids[len(ids)-1], nil
+	}
+
+	id, exists, err := lookupSlug(ctx, db, opts, slug)
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		return "", fmt.Errorf("%w: %q", ErrSlugNotFound, slug)
+	}
+
+	return id, nil
+}
+
+// FindSimilar returns the id → slug map of the records occupying the slug of the value or one of its suffixed
+// variants, the candidates Generate resolves collisions against. Useful to show who holds a slug before a rename.
+// Slugs of the tables given with WithReservedFromTable aren't records and are left out.
+func (s *Sluggable) FindSimilar(db contextExecutor, value string, options ...sluggableOption) (map[string]string, error) {
+	return s.FindSimilarContext(context.Background(), db, value, options...)
+}
+
+func (s *Sluggable) FindSimilarContext(ctx context.Context, db contextExecutor, value string, options ...sluggableOption) (map[string]string, error) {
+	if db == nil {
+		return nil, fmt.Errorf("[sluggable] db cannot be nil when finding similar slugs")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, slug, err := opts.baseSlug(value)
+	if err != nil {
+		return nil, err
+	}
+
+	simularList, _, err := querySimilar(ctx, db, opts, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	// The slugs of the reserved tables are keyed with fake identifiers, they aren't records
+	for id := range simularList {
+		if strings.HasPrefix(id, "\x00") {
+			delete(simularList, id)
+		}
+	}
+
+	return simularList, nil
+}
+
+// lookupSlug returns the identifier of the first row with exactly the given slug.
+func lookupSlug(ctx context.Context, db contextExecutor, opts options, slug string) (string, bool, error) {
+	query, params, err := buildExistsQuery(opts, slug)
+	if err != nil {
+		return "", false, err
+	}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", params)
+	}
+
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return "", false, fmt.Errorf("[sluggable] failed to query sluggable: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", false, fmt.Errorf("[sluggable] failed to iterate sluggable rows: %w", err)
+		}
+
+		return "", false, nil
+	}
+
+	var idValue any
+	if err := rows.Scan(&idValue); err != nil {
+		return "", false, fmt.Errorf("[sluggable] failed to scan sluggable value: %w", err)
+	}
+
+	return identifierString(idValue), true, nil
+}
+
+// queryCurrentSlug returns the stored slug of the record, "" when it has none yet.
+func queryCurrentSlug(ctx context.Context, db contextExecutor, opts options) (string, error) {
+	query, err := buildCurrentSlugQuery(opts)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", []any{opts.identifier})
+	}
+
+	var current sql.NullString
+	if err := db.QueryRowContext(ctx, query, opts.identifier).Scan(&current); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("[sluggable] failed to query current slug: %w", err)
+	}
+
+	return current.String, nil
+}
+
+// approveChange asks the approval callback before the stored slug of the record is replaced.
+// Records without a slug yet have no live URL, so they don't need approval.
+func (o options) approveChange(current, slug string) error {
+	if o.approval == nil || current == "" || current == slug {
+		return nil
+	}
+
+	approved, err := o.approval(current, slug)
+	if err != nil {
+		return err
+	}
+
+	if !approved {
+		return fmt.Errorf("%w: %q to %q", ErrChangeNotApproved, current, slug)
+	}
+
+	return nil
+}
+
+// acquireAdvisoryLock takes a transaction scoped Postgres advisory lock on the base slug,
+// serializing generation of the same slug until the transaction ends.
+func acquireAdvisoryLock(ctx context.Context, db contextExecutor, opts options, slug string) error {
+	if opts.getDialect().name != Postgres.name {
+		return fmt.Errorf("[sluggable] advisory locks are only supported on postgres, got %s", opts.getDialect())
+	}
+
+	query := "SELECT pg_advisory_xact_lock(hashtext($1))"
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", []any{opts.unsuffixed(slug)})
+	}
+
+	if _, err := db.ExecContext(ctx, query, opts.unsuffixed(slug)); err != nil {
+		return fmt.Errorf("[sluggable] failed to acquire advisory lock: %w", err)
+	}
+
+	return nil
+}
+
+// setStatementTimeout limits the following statements of the transaction to the configured timeout and
+// returns a function restoring the previous timeout, so the caller's own statements aren't limited.
+func setStatementTimeout(ctx context.Context, db contextExecutor, opts options) (func() error, error) {
+	if opts.getDialect().name != Postgres.name {
+		return nil, fmt.Errorf("[sluggable] statement timeouts are only supported on postgres, got %s", opts.getDialect())
+	}
+
+	current := "SELECT current_setting('statement_timeout')"
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", current)
+	}
+
+	var previous string
+	if err := db.QueryRowContext(ctx, current).Scan(&previous); err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to query statement timeout: %w", err)
+	}
+
+	// SET doesn't accept placeholders, a timeout of 0 would disable it
+	milliseconds := opts.statementTimeout.Milliseconds()
+	if milliseconds < 1 {
+		milliseconds = 1
+	}
+
+	query := fmt.Sprintf("SET LOCAL statement_timeout = %d", milliseconds)
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+	}
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to set statement timeout: %w", err)
+	}
+
+	return func() error {
+		// set_config with is_local behaves like SET LOCAL and accepts the previous value as a param
+		restore := "SELECT set_config('statement_timeout', $1, true)"
+
+		if opts.debug {
+			fmt.Printf("[sluggable] %s\n", restore)
+			fmt.Printf("[sluggable] %v\n", []any{previous})
+		}
+
+		if _, err := db.ExecContext(ctx, restore, previous); err != nil {
+			return fmt.Errorf("[sluggable] failed to restore statement timeout: %w", err)
+		}
+
+		return nil
+	}, nil
+}
+
+// querySimilar returns the id → slug map of all rows whose slug equals or starts with the given slug,
+// together with the executed query.
+func querySimilar(ctx context.Context, db contextExecutor, opts options, slug string) (map[string]string, string, error) {
+	if db == nil {
+		return map[string]string{}, "", nil
+	}
+
+	query, params, err := buildSimilarQuery(opts, slug)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", params)
+	}
+
+	if err := opts.injectFault(ctx, FaultLookup); err != nil {
+		return nil, "", fmt.Errorf("[sluggable] failed to query sluggable: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, "", fmt.Errorf("[sluggable] failed to query sluggable: %w", err)
+	}
+	defer rows.Close()
+
+	decode := opts.rowDecoder
+	if decode == nil {
+		decode = decodeRow
+	}
+
+	simularList := make(map[string]string)
+
+	for candidates := 1; rows.Next(); candidates++ {
+		if opts.maxCandidateRows > 0 && candidates > opts.maxCandidateRows {
+			return nil, "", fmt.Errorf("%w: more than %d rows match %q", ErrTooManyCandidates, opts.maxCandidateRows, opts.unsuffixed(slug))
+		}
+
+		id, slug, err := decode(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("[sluggable] failed to scan sluggable value: %w", err)
+		}
+
+		// Rows without a slug can't collide
+		if slug == "" {
+			continue
+		}
+
+		simularList[id] = slug
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("[sluggable] failed to iterate sluggable rows: %w", err)
+	}
+
+	for _, table := range opts.reservedTables {
+		reserved, err := queryReservedTable(ctx, db, opts, table, slug, false)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Keyed so they never match a record identifier
+		for i, reservedSlug := range reserved {
+			simularList[fmt.Sprintf("\x00%s:%d", table.table, i)] = reservedSlug
+		}
+	}
+
+	if opts.maxCandidateRows > 0 && len(simularList) > opts.maxCandidateRows {
+		return nil, "", fmt.Errorf("%w: more than %d rows match %q", ErrTooManyCandidates, opts.maxCandidateRows, opts.unsuffixed(slug))
+	}
+
+	return simularList, query, nil
+}
+
+// queryReservedTable returns the slugs of the reserved table equal to the slug or, unless exact, one of
+// its suffixed variants.
+func queryReservedTable(ctx context.Context, db contextExecutor, opts options, table reservedTable, slug string, exact bool) ([]string, error) {
+	query, params, err := buildReservedQuery(opts, table, slug, exact)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.debug {
+		fmt.Printf("[sluggable] %s\n", query)
+		fmt.Printf("[sluggable] %v\n", params)
+	}
+
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to query reserved slugs: %w", err)
+	}
+	defer rows.Close()
+
+	var reserved []string
+
+	for rows.Next() {
+		var reservedSlug sql.NullString
+		if err := rows.Scan(&reservedSlug); err != nil {
+			return nil, fmt.Errorf("[sluggable] failed to scan reserved slug: %w", err)
+		}
+
+		if reservedSlug.String != "" {
+			reserved = append(reserved, reservedSlug.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("[sluggable] failed to iterate reserved slugs: %w", err)
+	}
+
+	return reserved, nil
+}
+
+// decodeRow scans the identifier and slug columns, NULL slugs are returned as "".
+func decodeRow(rows *sql.Rows) (string, string, error) {
+	var idValue any
+
+	var slugValue sql.NullString
+	if err := rows.Scan(&idValue, &slugValue); err != nil {
+		return "", "", err
+	}
+
+	return identifierString(idValue), slugValue.String, nil
+}
+
+// identifierString normalizes identifiers such as int64, []byte or UUIDs to a string.
+func identifierString(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(typed)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// buildSimilarQuery builds the query selecting all rows whose slug equals or starts with the given slug.
+func buildSimilarQuery(opts options, slug string) (string, []any, error) {
+	if err := opts.validateIdentifiers(); err != nil {
+		return "", nil, err
+	}
+
+	dialect := opts.getDialect()
+
+	where, params, err := buildSimilarWhere(opts, slug, 1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// One row more than allowed is enough to tell that there are too many
+	top, limit := "", ""
+	if opts.maxCandidateRows > 0 {
+		top, limit = dialect.limit(opts.maxCandidateRows + 1)
+	}
+
+	query := fmt.Sprintf(`SELECT %s%s, %s FROM %s%s WHERE %s%s%s`,
+		top, dialect.quote(opts.identifierColumn), dialect.quote(opts.columnName), opts.qualifiedTable(), dialect.lockHint[opts.lock],
+		where, limit, dialect.lockSuffix[opts.lock],
+	)
+
+	if opts.queryBuilder != nil {
+		return buildCustomSimilarQuery(opts, slug, query)
+	}
+
+	return query, params, nil
+}
+
+// buildSimilarWhere builds the conditions matching the rows whose slug equals or starts with the given slug,
+// numbering the placeholders from start.
+func buildSimilarWhere(opts options, slug string, start int) (string, []any, error) {
+	where, params := collisionPredicate(opts, slug, start)
+
+	conditions, params, err := buildWhereConditions(opts, params, start-1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, condition := range conditions {
+		where += fmt.Sprintf(" AND (%s)", condition)
+	}
+
+	return where, params, nil
+}
+
+// collisionPredicate matches the slug and all its suffixed variants.
+func collisionPredicate(opts options, slug string, start int) (string, []any) {
+	dialect := opts.getDialect()
+	column := opts.slugColumn()
+
+	pattern := opts.likePattern(slug)
+
+	// Only escaped patterns need the clause, which keeps the common query unchanged
+	escape := ""
+	if strings.Contains(pattern, likeEscape) {
+		escape = fmt.Sprintf(" ESCAPE '%s'", likeEscape)
+	}
+
+	predicate := fmt.Sprintf(`(%s = %s OR %s LIKE %s%s)`, column, dialect.placeholder(start), column, dialect.placeholder(start+1), escape)
+
+	return predicate, []any{opts.foldCase(opts.unsuffixed(slug)), opts.foldCase(pattern)}
+}
+
+// slugColumn returns the quoted slug column, lowered when comparing case-insensitively.
+func (o options) slugColumn() string {
+	column := o.getDialect().quote(o.columnName)
+	if o.caseInsensitive {
+		return "LOWER(" + column + ")"
+	}
+
+	return column
+}
+
+// CollisionPredicate returns the condition matching the base slug and all its suffixed variants, the same
+// matching Generate uses but without the where clauses, e.g. for reporting or cleanup queries across scopes.
+// Placeholders are numbered from WithParamStartIndex. With WithTenantResolver the tenant has to be passed with
+// WithTenant, even though it isn't part of the predicate.
+func (s *Sluggable) CollisionPredicate(base string, options ...sluggableOption) (string, []any, error) {
+	opts, err := s.builderOptions(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	predicate, params := collisionPredicate(opts, base, opts.paramStartIndex)
+
+	return predicate, params, nil
+}
+
+// SimilarWhere returns the WHERE conditions Generate uses to find the slugs similar to the given slug,
+// together with their params, to embed them in larger queries. Placeholders are numbered from
+// WithParamStartIndex. The dialect is taken from WithDialect or WithDriverName, Postgres otherwise.
+func (s *Sluggable) SimilarWhere(slug string, options ...sluggableOption) (string, []any, error) {
+	opts, err := s.builderOptions(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return buildSimilarWhere(opts, slug, opts.paramStartIndex)
+}
+
+// builderOptions resolves the options of the query builders, which run without a db.
+func (s *Sluggable) builderOptions(options []sluggableOption) (options, error) {
+	opts := s.options // Important: copy instead of pointer reference
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if opts.dialect.name == "" {
+		opts.dialect, _ = dialectFromDriverName(opts.driverName)
+	}
+
+	if opts.paramStartIndex < 1 {
+		return opts, fmt.Errorf("[sluggable] param start index must be at least 1, got %d", opts.paramStartIndex)
+	}
+
+	// Without a context the resolver can't run, and a query without the tenant would span every tenant
+	if err := opts.requireExplicitTenant(); err != nil {
+		return opts, err
+	}
+
+	return opts, opts.validateIdentifiers()
+}
+
+// buildWhereConditions renders the configured where clauses, numbering their placeholders after the given params
+// and offset.
+func buildWhereConditions(opts options, params []any, offset int) ([]string, []any, error) {
+	dialect := opts.getDialect()
+	conditions := make([]string, 0, len(opts.wheres))
+
+	for _, where := range opts.wheres {
+		if where.SQL == excludeDeletedWhere {
+			if opts.softDeleteBoolean {
+				params = append(params, opts.softDeleteLive)
+				conditions = append(conditions, fmt.Sprintf("%s = %s", dialect.quote(opts.softDeleteColumn), dialect.placeholder(offset+len(params))))
+
+				continue
+			}
+
+			conditions = append(conditions, fmt.Sprintf("%s IS NULL", dialect.quote(opts.softDeleteColumn)))
+
+			continue
+		}
+
+		clause := where.SQL
+		if where.render != nil {
+			clause = where.render(dialect)
+		}
+
+		normalizedSql, err := bindPlaceholders(dialect, clause, where.Args, offset+len(params))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		conditions = append(conditions, normalizedSql)
+		params = append(params, where.Args...)
+	}
+
+	for _, scope := range opts.scopes {
+		if scope.value == nil {
+			conditions = append(conditions, fmt.Sprintf("%s IS NULL", dialect.quote(scope.column)))
+
+			continue
+		}
+
+		params = append(params, scope.value)
+		conditions = append(conditions, fmt.Sprintf("%s = %s", dialect.quote(scope.column), dialect.placeholder(offset+len(params))))
+	}
+
+	return conditions, params, nil
+}
+
+// qualifiedTable returns the quoted table name, qualified with the schema when one is set.
+func (o options) qualifiedTable() string {
+	dialect := o.getDialect()
+
+	table := dialect.quote(o.tableName)
+	if o.schema != "" {
+		table = dialect.quote(o.schema) + "." + table
+	}
+
+	return table
+}
+
+// buildExistsQuery builds the query selecting the rows with exactly the given slug, except the configured identifier.
+func buildExistsQuery(opts options, slug string) (string, []any, error) {
+	if err := opts.validateIdentifiers(); err != nil {
+		return "", nil, err
+	}
+
+	dialect := opts.getDialect()
+	identifierColumn := dialect.quote(opts.identifierColumn)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE (%s = %s)`,
+		identifierColumn, opts.qualifiedTable(), opts.slugColumn(), dialect.placeholder(1),
+	)
+
+	conditions, params, err := buildWhereConditions(opts, []any{opts.foldCase(slug)}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if identifierString(opts.identifier) != "" {
+		params = append(params, opts.identifier)
+		conditions = append(conditions, fmt.Sprintf("%s <> %s", identifierColumn, dialect.placeholder(len(params))))
+	}
+
+	for _, condition := range conditions {
+		query += fmt.Sprintf(" AND (%s)", condition)
+	}
+
+	return query, params, nil
+}
+
+// buildCurrentSlugQuery builds the query reading the stored slug of the row with the configured identifier.
+func buildCurrentSlugQuery(opts options) (string, error) {
+	if err := opts.validateIdentifiers(); err != nil {
+		return "", err
+	}
+
+	dialect := opts.getDialect()
+
+	return fmt.Sprintf(`SELECT %s FROM %s WHERE %s = %s`,
+		dialect.quote(opts.columnName), opts.qualifiedTable(),
+		dialect.quote(opts.identifierColumn), dialect.placeholder(1),
+	), nil
+}
+
+// buildReservedQuery builds the query selecting the slugs of the reserved table that equal the slug or,
+// unless exact, one of its suffixed variants. The where clauses of the sluggable table don't apply.
+func buildReservedQuery(opts options, table reservedTable, slug string, exact bool) (string, []any, error) {
+	if err := opts.validateIdentifiers(); err != nil {
+		return "", nil, err
+	}
+
+	reservedOpts := opts
+	reservedOpts.tableName, reservedOpts.columnName = table.table, table.column
+
+	dialect := opts.getDialect()
+	column := reservedOpts.slugColumn()
+
+	if exact {
+		return fmt.Sprintf(`SELECT %s FROM %s WHERE %s = %s`,
+			dialect.quote(table.column), reservedOpts.qualifiedTable(), column, dialect.placeholder(1),
+		), []any{opts.foldCase(slug)}, nil
+	}
+
+	predicate, params := collisionPredicate(reservedOpts, slug, 1)
+
+	return fmt.Sprintf(`SELECT %s FROM %s WHERE %s`, dialect.quote(table.column), reservedOpts.qualifiedTable(), predicate), params, nil
+}
+
+// buildUpdateQuery builds the query storing the slug of the row with the configured identifier.
+func buildUpdateQuery(opts options) (string, error) {
+	if err := opts.validateIdentifiers(); err != nil {
+		return "", err
+	}
+
+	dialect := opts.getDialect()
+
+	table := opts.qualifiedTable()
+
+	return fmt.Sprintf(`UPDATE %s SET %s = %s WHERE %s = %s`,
+		table, dialect.quote(opts.columnName), dialect.placeholder(1),
+		dialect.quote(opts.identifierColumn), dialect.placeholder(2),
+	), nil
+}
+
+// bindPlaceholders rewrites every "?" outside of quoted strings and identifiers to the dialect's placeholder,
+// numbered after the given offset. "??" is written as a literal "?", e.g. for the Postgres JSONB operators
+// ("??", "??|", "??&"). The number of placeholders must match the number of args.
+func bindPlaceholders(dialect Dialect, clause string, args []any, offset int) (string, error) {
+	var builder strings.Builder
+
+	var quote rune
+
+	count := 0
+	runes := []rune(clause)
+
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '\'' || char == '"' || char == '`':
+			quote = char
+		case char == '?' && i+1 < len(runes) && runes[i+1] == '?':
+			i++
+		case char == '?':
+			count++
+			builder.WriteString(dialect.placeholder(offset + count))
+
+			continue
+		}
+
+		builder.WriteRune(char)
+	}
+
+	if count != len(args) {
+		return "", fmt.Errorf("[sluggable] where clause %q has %d placeholders but %d params", clause, count, len(args))
+	}
+
+	return builder.String(), nil
+}
+
+// validateIdentifiers guards the names that are interpolated into the query.
+func (o options) validateIdentifiers() error {
+	type namedIdentifier struct {
+		kind  string
+		value string
+	}
+
+	identifiers := []namedIdentifier{
+		{kind: "column name", value: o.columnName},
+		{kind: "identifier column", value: o.identifierColumn},
+	}
+
+	// An empty table name is reported by Generate itself, the schema, source column and pending table are optional
+	if o.tableName != "" {
+		identifiers = append(identifiers, namedIdentifier{kind: "table name", value: o.tableName})
+	}
+
+	if o.schema != "" {
+		identifiers = append(identifiers, namedIdentifier{kind: "schema", value: o.schema})
+	}
+
+	if o.sourceColumn != "" {
+		identifiers = append(identifiers, namedIdentifier{kind: "source column", value: o.sourceColumn})
+	}
+
+	for _, where := range o.wheres {
+		if where.SQL == excludeDeletedWhere {
+			identifiers = append(identifiers, namedIdentifier{kind: "soft delete column", value: o.softDeleteColumn})
+		}
+
+		if where.column != "" {
+			identifiers = append(identifiers, namedIdentifier{kind: "where column", value: where.column})
+		}
+	}
+
+	if o.parentColumn != "" {
+		identifiers = append(identifiers, namedIdentifier{kind: "parent column", value: o.parentColumn})
+	}
+
+	if o.pendingTable != "" {
+		identifiers = append(identifiers, namedIdentifier{kind: "pending table", value: o.pendingTable})
+	}
+
+	for _, scope := range o.scopes {
+		identifiers = append(identifiers, namedIdentifier{kind: "scope column", value: scope.column})
+	}
+
+	for _, table := range o.reservedTables {
+		identifiers = append(identifiers,
+			namedIdentifier{kind: "reserved table", value: table.table},
+			namedIdentifier{kind: "reserved column", value: table.column},
+		)
+	}
+
+	for _, identifier := range identifiers {
+		if !isValidIdentifier(identifier.value) {
+			return fmt.Errorf("%w: %s %q", ErrInvalidIdentifier, identifier.kind, identifier.value)
+		}
+	}
+
+	return nil
+}
+
+// isValidIdentifier only allows letters, digits, "_", "$" and "-", which rules out quotes, semicolons,
+// whitespace and dots.
+func isValidIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+
+	for _, char := range identifier {
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '_' && char != '$' && char != '-' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,55 @@
+package sluggable
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// WithSubstitutions replaces text in the value before slugifying, e.g. {"&": "and", "%": "percent"}
+// turns "Tom & Jerry 50% off" into "tom-and-jerry-50-percent-off". Replacements become separate words,
+// longer keys win over shorter ones. Repeated calls add to the substitutions.
+func WithSubstitutions(substitutions map[string]string) sluggableOption {
+	return func(opts *options) {
+		// Copy so options sharing the same map aren't changed
+		merged := make(map[string]string, len(opts.substitutions)+len(substitutions))
+		for from, to := range opts.substitutions {
+			merged[from] = to
+		}
+
+		for from, to := range substitutions {
+			if from != "" {
+				merged[from] = to
+			}
+		}
+
+		opts.substitutions = merged
+	}
+}
+
+// substitute applies the substitutions, trying longer keys first.
+func (o options) substitute(value string) string {
+	if len(o.substitutions) == 0 {
+		return value
+	}
+
+	keys := make([]string, 0, len(o.substitutions))
+	for from := range o.substitutions {
+		keys = append(keys, from)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if lengthI, lengthJ := utf8.RuneCountInString(keys[i]), utf8.RuneCountInString(keys[j]); lengthI != lengthJ {
+			return lengthI > lengthJ
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, 2*len(keys))
+	for _, from := range keys {
+		pairs = append(pairs, from, " "+o.substitutions[from]+" ")
+	}
+
+	return strings.NewReplacer(pairs...).Replace(value)
+}
@@ -0,0 +1,45 @@
+package sluggable
+
+import "testing"
+
+func TestWithSubstitutions(t *testing.T) {
+	common := map[string]string{"&": "and", "%": "percent", "@": "at"}
+
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    string
+	}{
+		{name: "none", value: "50% off", want: "50-off"},
+		{name: "words", options: []sluggableOption{WithSubstitutions(common)}, value: "Tom & Jerry 50% off", want: "tom-and-jerry-50-percent-off"},
+		{name: "without spaces", options: []sluggableOption{WithSubstitutions(common)}, value: "Q&A@home", want: "q-and-a-at-home"},
+		{
+			name:    "longer keys first",
+			options: []sluggableOption{WithSubstitutions(map[string]string{"+": "plus", "C++": "cpp"})},
+			value:   "C++ vs C+",
+			want:    "cpp-vs-c-plus",
+		},
+		{
+			name:    "repeated calls add",
+			options: []sluggableOption{WithSubstitutions(map[string]string{"&": "and"}), WithSubstitutions(map[string]string{"%": "pct"})},
+			value:   "A & B 10%",
+			want:    "a-and-b-10-pct",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := New(tt.options...).options.makeSlug(tt.value); got != tt.want {
+				t.Errorf("makeSlug(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	shared := map[string]string{"&": "and"}
+	New(WithSubstitutions(shared), WithSubstitutions(map[string]string{"%": "percent"}))
+
+	if len(shared) != 1 {
+		t.Errorf("WithSubstitutions() changed the given map to %v", shared)
+	}
+}
@@ -0,0 +1,214 @@
+package sluggable
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const crockfordAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// SuffixStrategy decides which suffix is appended to a taken slug.
+type SuffixStrategy struct {
+	name   string
+	length int // Used by the random and hash strategies
+	suffix func(request suffixRequest) (string, error)
+}
+
+type suffixRequest struct {
+	ctx        context.Context
+	base       string // The slug without suffix
+	value      string // The value before slugifying
+	identifier string
+	attempt    int // Starts at 0, incremented when the previous suffix was taken
+}
+
+var (
+	// NumericSuffix appends the number following the highest suffix in use ("hello-world-3"), the default.
+	NumericSuffix = SuffixStrategy{name: "numeric"}
+	// ULIDSuffix appends a lowercase ULID, sortable by creation time.
+	ULIDSuffix = SuffixStrategy{name: "ulid", suffix: func(suffixRequest) (string, error) { return newULID(time.Now()) }}
+)
+
+// RandomSuffix appends a random base62 value of the given length, so suffixes don't leak how many
+// records share a base.
+func RandomSuffix(length int) SuffixStrategy {
+	return SuffixStrategy{
+		name:   "random",
+		length: length,
+		suffix: func(suffixRequest) (string, error) { return randomBase62(length) },
+	}
+}
+
+// HashSuffix appends the first length hex characters of a SHA-256 of the value and identifier,
+// so the same record gets the same suffix. Taken hashes are rehashed with the attempt number.
+func HashSuffix(length int) SuffixStrategy {
+	return SuffixStrategy{
+		name:   "hash",
+		length: length,
+		suffix: func(request suffixRequest) (string, error) {
+			input := request.value + "\x00" + request.identifier
+			if request.attempt > 0 {
+				input += "\x00" + strconv.Itoa(request.attempt)
+			}
+
+			sum := sha256.Sum256([]byte(input))
+
+			return hex.EncodeToString(sum[:])[:length], nil
+		},
+	}
+}
+
+func (s SuffixStrategy) String() string {
+	if s.length > 0 {
+		return fmt.Sprintf("%s(%d)", s.name, s.length)
+	}
+
+	return s.name
+}
+
+func (s SuffixStrategy) validate() error {
+	switch {
+	case s.name == "random" && s.length <= 0:
+		return fmt.Errorf("[sluggable] random suffix length must be positive, got %d", s.length)
+	case s.name == "hash" && (s.length <= 0 || s.length > 2*sha256.Size):
+		return fmt.Errorf("[sluggable] hash suffix length must be between 1 and %d, got %d", 2*sha256.Size, s.length)
+	}
+
+	return nil
+}
+
+// WithSuffixStrategy sets how taken slugs are suffixed. WithFirstUniqueSuffix only applies to NumericSuffix.
+func WithSuffixStrategy(strategy SuffixStrategy) sluggableOption {
+	return func(opts *options) {
+		opts.suffixStrategy = strategy
+	}
+}
+
+// WithMaxCollisionSuffix limits numeric suffixes to n. Beyond it taken slugs are suffixed with the
+// WithCollisionFallback strategy, RandomSuffix(8) by default.
+func WithMaxCollisionSuffix(n int) sluggableOption {
+	return func(opts *options) {
+		opts.maxCollisionSuffix = n
+	}
+}
+
+// WithCollisionFallback sets the strategy used past WithMaxCollisionSuffix. With NumericSuffix,
+// ErrCollisionLimitExceeded is returned instead.
+func WithCollisionFallback(strategy SuffixStrategy) sluggableOption {
+	return func(opts *options) {
+		opts.collisionFallback = strategy
+	}
+}
+
+// WithSuffixWarning calls warn when a slug gets a numeric suffix above limit, e.g. to alert that a title
+// template floods a namespace. Unlike WithMaxCollisionSuffix the generation never fails or switches strategy.
+func WithSuffixWarning(limit int, warn func(ctx context.Context, result Result)) sluggableOption {
+	return func(opts *options) {
+		opts.suffixWarningLimit = limit
+		opts.suffixWarning = warn
+	}
+}
+
+// WithSuffixFunc suffixes taken slugs with the result of fn, e.g. a nanoid or a store code.
+// attempt starts at 0 and is incremented as long as the returned suffix is taken.
+func WithSuffixFunc(fn func(base string, attempt int) string) sluggableOption {
+	return WithSuffixFuncContext(func(_ context.Context, base string, attempt int) (string, error) {
+		return fn(base, attempt), nil
+	})
+}
+
+// WithSuffixFuncContext is like WithSuffixFunc, fn receives the context of the GenerateContext call
+// (e.g. to read the tenant) and can fail.
+func WithSuffixFuncContext(fn func(ctx context.Context, base string, attempt int) (string, error)) sluggableOption {
+	return WithSuffixStrategy(SuffixStrategy{
+		name: "func",
+		suffix: func(request suffixRequest) (string, error) {
+			return fn(request.ctx, request.base, request.attempt)
+		},
+	})
+}
+
+// nextCandidate returns the suffixed slug to try after the given similar slugs, together with the
+// numeric suffix when the numeric strategy is used.
+func (o options) nextCandidate(ctx context.Context, slug string, simulars []string, attempt int) (string, int, error) {
+	strategy := o.suffixStrategy
+
+	if strategy.suffix == nil {
+		suffix := o.nextSuffix(slug, simulars)
+		if o.maxCollisionSuffix <= 0 || suffix <= o.maxCollisionSuffix {
+			return o.suffixed(slug, suffix), suffix, nil
+		}
+
+		// Past the limit the fallback takes over instead of counting ever higher
+		strategy = o.collisionFallback
+		if strategy.suffix == nil {
+			return "", 0, fmt.Errorf("%w: suffix %d of %q is above %d", ErrCollisionLimitExceeded, suffix, slug, o.maxCollisionSuffix)
+		}
+	}
+
+	if err := strategy.validate(); err != nil {
+		return "", 0, err
+	}
+
+	suffix, err := strategy.suffix(suffixRequest{
+		ctx:        ctx,
+		base:       slug,
+		value:      o.value,
+		identifier: identifierString(o.identifier),
+		attempt:    attempt,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	if suffix == "" {
+		return "", 0, fmt.Errorf("[sluggable] %s suffix strategy returned an empty suffix", strategy)
+	}
+
+	return fmt.Sprint(slug, o.getSuffixSeparator(), suffix, o.extension), 0, nil
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("[sluggable] failed to generate random value: %w", err)
+	}
+
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	encoded := hex.EncodeToString(id[:])
+
+	return encoded[:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:], nil
+}
+
+// newULID encodes a 48 bit millisecond timestamp and 80 random bits in lowercase Crockford base32.
+func newULID(now time.Time) (string, error) {
+	var id [16]byte
+
+	binary.BigEndian.PutUint64(id[:8], uint64(now.UnixMilli())<<16)
+
+	if _, err := rand.Read(id[6:]); err != nil {
+		return "", fmt.Errorf("[sluggable] failed to generate random value: %w", err)
+	}
+
+	// 128 bits in 26 characters of 5 bits, the first character only holds 3 bits
+	encoded := make([]byte, 26)
+	high := binary.BigEndian.Uint64(id[:8])
+	low := binary.BigEndian.Uint64(id[8:])
+
+	for i := len(encoded) - 1; i >= 0; i-- {
+		encoded[i] = crockfordAlphabet[low&0x1f]
+		low = low>>5 | high<<59
+		high >>= 5
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,344 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithSuffixStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy SuffixStrategy
+		want     *regexp.Regexp
+	}{
+		{name: "numeric", strategy: NumericSuffix, want: regexp.MustCompile(`^hello-world-3$`)},
+		{name: "random", strategy: RandomSuffix(6), want: regexp.MustCompile(`^hello-world-[0-9A-Za-z]{6}$`)},
+		{name: "hash", strategy: HashSuffix(8), want: regexp.MustCompile(`^hello-world-[0-9a-f]{8}$`)},
+		{name: "ulid", strategy: ULIDSuffix, want: regexp.MustCompile(`^hello-world-[0-9a-hjkmnp-tv-z]{26}$`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "posts"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "hello-world").AddRow("2", "hello-world-2"))
+
+			got, err := New(WithSuffixStrategy(tt.strategy)).Generate(db, "Hello World", WithTableName("posts"))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if !tt.want.MatchString(got) {
+				t.Errorf("Sluggable.Generate() = %v, want match for %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestHashSuffix(t *testing.T) {
+	opts := getDefaultOptions()
+	opts.suffixStrategy = HashSuffix(8)
+	opts.value = "Hello World"
+	opts.identifier = 42
+
+	first, _, err := opts.nextCandidate(context.Background(), "hello-world", []string{"hello-world"}, 0)
+	if err != nil {
+		t.Fatalf("nextCandidate() error = %v", err)
+	}
+
+	again, _, _ := opts.nextCandidate(context.Background(), "hello-world", []string{"hello-world"}, 0)
+	if first != again {
+		t.Errorf("nextCandidate() = %v, want the stable %v", again, first)
+	}
+
+	// A taken hash is rehashed, so the next attempt must differ
+	retried, _, _ := opts.nextCandidate(context.Background(), "hello-world", []string{"hello-world", first}, 1)
+	if retried == first {
+		t.Errorf("nextCandidate() = %v on retry, want a different suffix", retried)
+	}
+}
+
+func TestSuffixStrategy_Validate(t *testing.T) {
+	for _, strategy := range []SuffixStrategy{RandomSuffix(0), HashSuffix(-1), HashSuffix(65)} {
+		if _, err := NewStrict(WithSuffixStrategy(strategy)); err == nil {
+			t.Errorf("NewStrict(WithSuffixStrategy(%v)) should fail", strategy)
+		}
+	}
+
+	if got := New(WithSuffixStrategy(RandomSuffix(8))).Options().SuffixStrategy; got != "random(8)" {
+		t.Errorf("Options().SuffixStrategy = %v, want %v", got, "random(8)")
+	}
+}
+
+func TestNewULID(t *testing.T) {
+	now := time.UnixMilli(1469918176385)
+
+	got, err := newULID(now)
+	if err != nil {
+		t.Fatalf("newULID() error = %v", err)
+	}
+
+	// 1469918176385 encodes to "01ARYZ6S41" in the ULID spec
+	if len(got) != 26 || !strings.HasPrefix(got, "01aryz6s41") {
+		t.Errorf("newULID() = %v, want 26 characters starting with the encoded timestamp", got)
+	}
+}
+
+func TestWithSuffixFunc(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "stores"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "main-street").AddRow("2", "main-street-nyc"))
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "stores"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "main-street"))
+
+	storeCodes := []string{"nyc", "bos"}
+
+	var calls []string
+
+	s := New(WithTableName("stores"), WithSuffixFunc(func(base string, attempt int) string {
+		calls = append(calls, fmt.Sprint(base, ":", attempt))
+
+		return storeCodes[attempt]
+	}))
+
+	got, err := s.Generate(db, "Main Street")
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "main-street-bos" || strings.Join(calls, ",") != "main-street:0,main-street:1" {
+		t.Errorf("Sluggable.Generate() = %v after %v, want main-street-bos after skipping the taken nyc", got, calls)
+	}
+
+	_, err = New(WithTableName("stores"), WithSuffixFunc(func(string, int) string { return "" })).Generate(db, "Main Street")
+	if err == nil || !strings.Contains(err.Error(), "empty suffix") {
+		t.Errorf("Sluggable.Generate() error = %v, want empty suffix error", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithSuffixFormat(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "reports"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "q3-report").AddRow("2", "q3-report-009"))
+
+	got, err := New(WithSuffixFormat("%03d")).Generate(db, "Q3 Report", WithTableName("reports"))
+	if err != nil {
+		t.Fatalf("Sluggable.Generate() error = %v", err)
+	}
+
+	if got != "q3-report-010" {
+		t.Errorf("Sluggable.Generate() = %v, want %v", got, "q3-report-010")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	for _, format := range []string{"%x", "v%d", "%s", "%+d", "%+03d"} {
+		if _, err := NewStrict(WithSuffixFormat(format)); err == nil {
+			t.Errorf("NewStrict(WithSuffixFormat(%q)) should fail", format)
+		}
+	}
+}
+
+func TestWithMaxCollisionSuffix(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		want    *regexp.Regexp
+		wantErr error
+	}{
+		{name: "below the limit", options: []sluggableOption{WithMaxCollisionSuffix(10)}, want: regexp.MustCompile(`^hello-world-4$`)},
+		{name: "random fallback", options: []sluggableOption{WithMaxCollisionSuffix(3)}, want: regexp.MustCompile(`^hello-world-[0-9A-Za-z]{8}$`)},
+		{
+			name:    "hash fallback",
+			options: []sluggableOption{WithMaxCollisionSuffix(3), WithCollisionFallback(HashSuffix(6))},
+			want:    regexp.MustCompile(`^hello-world-[0-9a-f]{6}$`),
+		},
+		{
+			name:    "numeric fallback fails",
+			options: []sluggableOption{WithMaxCollisionSuffix(3), WithCollisionFallback(NumericSuffix)},
+			wantErr: ErrCollisionLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "posts"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).
+					AddRow("1", "hello-world").AddRow("2", "hello-world-2").AddRow("3", "hello-world-3"))
+
+			got, err := New(tt.options...).Generate(db, "Hello World", WithTableName("posts"))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Sluggable.Generate() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if !tt.want.MatchString(got) {
+				t.Errorf("Sluggable.Generate() = %v, want match for %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSuffixWarning(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "below the limit", limit: 4, want: 0},
+		{name: "at the limit", limit: 3, want: 0},
+		{name: "above the limit", limit: 2, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "posts"`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).
+					AddRow("1", "hello-world").AddRow("2", "hello-world-2"))
+
+			var warnings []Result
+			warn := func(_ context.Context, result Result) { warnings = append(warnings, result) }
+
+			got, err := New(WithSuffixWarning(tt.limit, warn)).Generate(db, "Hello World", WithTableName("posts"))
+			if err != nil {
+				t.Fatalf("Sluggable.Generate() error = %v", err)
+			}
+
+			if got != "hello-world-3" {
+				t.Errorf("Sluggable.Generate() = %v, want %v", got, "hello-world-3")
+			}
+
+			if len(warnings) != tt.want {
+				t.Fatalf("warnings = %d, want %d", len(warnings), tt.want)
+			}
+
+			if tt.want > 0 && warnings[0].Suffix != 3 {
+				t.Errorf("warning suffix = %d, want %d", warnings[0].Suffix, 3)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestErrCollisionLimitExceeded(t *testing.T) {
+	taken := func(context.Context, string) (bool, error) { return false, nil }
+
+	_, err := New(WithAvailabilityChecker(taken)).Generate(nil, "Hello World")
+	if !errors.Is(err, ErrCollisionLimitExceeded) {
+		t.Errorf("Sluggable.Generate() error = %v, want %v", err, ErrCollisionLimitExceeded)
+	}
+}
+
+func TestWithMaxCandidateRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "slug"}).
+			AddRow("1", "hello-world").AddRow("2", "hello-world-2").AddRow("3", "hello-world-3")
+	}
+
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "posts" WHERE .* LIMIT 3$`).WillReturnRows(rows())
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "posts" WHERE .* LIMIT 4$`).WillReturnRows(rows())
+
+	s := New(WithTableName("posts"))
+
+	if _, err := s.Generate(db, "Hello World", WithMaxCandidateRows(2)); !errors.Is(err, ErrTooManyCandidates) {
+		t.Errorf("Sluggable.Generate() error = %v, want %v", err, ErrTooManyCandidates)
+	}
+
+	if got, err := s.Generate(db, "Hello World", WithMaxCandidateRows(3)); err != nil || got != "hello-world-4" {
+		t.Errorf("Sluggable.Generate() = %v, %v, want hello-world-4", got, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMaxCandidateRowsQuery(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{dialect: Postgres, want: `SELECT "id", "slug" FROM "posts" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) LIMIT 11 FOR UPDATE`},
+		{dialect: MySQL, want: "SELECT `id`, `slug` FROM `posts` WHERE (`slug` = ? OR `slug` LIKE ?) AND (`deleted_at` IS NULL) LIMIT 11 FOR UPDATE"},
+		{dialect: SQLite, want: `SELECT "id", "slug" FROM "posts" WHERE ("slug" = ? OR "slug" LIKE ?) AND ("deleted_at" IS NULL) LIMIT 11`},
+		{
+			dialect: SQLServer,
+			want:    `SELECT TOP (11) [id], [slug] FROM [posts] WITH (UPDLOCK, HOLDLOCK) WHERE ([slug] = @p1 OR [slug] LIKE @p2) AND ([deleted_at] IS NULL)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect.String(), func(t *testing.T) {
+			opts := New(WithTableName("posts"), WithDialect(tt.dialect), WithLock(LockForUpdate), WithMaxCandidateRows(10)).options
+
+			got, _, err := buildSimilarQuery(opts, "hello-world")
+			if err != nil {
+				t.Fatalf("buildSimilarQuery() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("buildSimilarQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
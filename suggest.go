@@ -0,0 +1,127 @@
+package sluggable
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Suggest returns up to n available slugs for the value, best first: the base slug, the next suffix,
+// the base with the current year, the base with the initials of its words and then further suffixes.
+// UIs can offer them as a choice instead of silently appending a number. Suggestions are previews,
+// see WithPreview.
+func (s *Sluggable) Suggest(db contextExecutor, value string, n int, options ...sluggableOption) ([]string, error) {
+	return s.SuggestContext(context.Background(), db, value, n, options...)
+}
+
+func (s *Sluggable) SuggestContext(ctx context.Context, db contextExecutor, value string, n int, options ...sluggableOption) ([]string, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("[sluggable] number of suggestions must be positive")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.preview = true
+	ctx = opts.previewContext(ctx)
+
+	opts, slug, err := opts.baseSlug(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every candidate starts with the base slug, so one lookup covers all of them
+	simularList, _, err := querySimilar(ctx, db, opts, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make([]string, 0, len(simularList))
+	for _, simular := range simularList {
+		taken = append(taken, simular)
+	}
+
+	suggestions := make([]string, 0, n)
+	seen := make(map[string]struct{})
+
+	suggest := func(candidate string) error {
+		if _, ok := seen[candidate]; ok || len(suggestions) >= n || opts.containsSlug(taken, candidate) {
+			return nil
+		}
+
+		seen[candidate] = struct{}{}
+
+		if opts.maxLength > 0 && utf8.RuneCountInString(candidate) > opts.maxLength || opts.checkConstraint(candidate) != nil {
+			return nil
+		}
+
+		available, err := opts.isAvailable(ctx, candidate)
+		if err != nil {
+			return err
+		}
+
+		if available {
+			suggestions = append(suggestions, candidate)
+		}
+
+		return nil
+	}
+
+	if err := suggest(opts.unsuffixed(slug)); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; len(suggestions) < n && attempt < n+maxAvailabilityAttempts; attempt++ {
+		candidate, _, err := opts.nextCandidate(ctx, slug, taken, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := suggest(candidate); err != nil {
+			return nil, err
+		}
+
+		// Suggested suffixes are handed out once, the next attempt continues after them
+		taken = append(taken, candidate)
+
+		if attempt > 0 {
+			continue
+		}
+
+		alternatives := []string{strconv.Itoa(time.Now().Year()), opts.initials(slug)}
+		for _, alternative := range alternatives {
+			if alternative == "" {
+				continue
+			}
+
+			if err := suggest(fmt.Sprint(slug, opts.getSuffixSeparator(), alternative, opts.extension)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return suggestions, nil
+}
+
+// initials returns the first character of every word of the slug, or "" for a single word.
+func (o options) initials(slug string) string {
+	words := strings.Split(slug, o.separator)
+	if len(words) < 2 {
+		return ""
+	}
+
+	var initials strings.Builder
+
+	for _, word := range words {
+		if r, _ := utf8.DecodeRuneInString(word); r != utf8.RuneError {
+			initials.WriteRune(r)
+		}
+	}
+
+	return initials.String()
+}
@@ -0,0 +1,77 @@
+package sluggable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSluggable_Suggest(t *testing.T) {
+	year := time.Now().Year()
+
+	tests := []struct {
+		name     string
+		existing []string
+		options  []sluggableOption
+		n        int
+		want     []string
+	}{
+		{
+			name: "base is free",
+			n:    3,
+			want: []string{"hello-world", "hello-world-2", fmt.Sprintf("hello-world-%d", year)},
+		},
+		{
+			name:     "base is taken",
+			existing: []string{"hello-world", "hello-world-2"},
+			n:        4,
+			want:     []string{"hello-world-3", fmt.Sprintf("hello-world-%d", year), "hello-world-hw", "hello-world-4"},
+		},
+		{
+			name:     "reserved candidates are skipped",
+			existing: []string{"hello-world"},
+			options:  []sluggableOption{WithReserved("hello-world-hw", "hello-world-3")},
+			n:        3,
+			want:     []string{"hello-world-2", fmt.Sprintf("hello-world-%d", year), "hello-world-4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for i, existing := range tt.existing {
+				rows.AddRow(fmt.Sprint(i+1), existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "posts"`).
+				WithArgs("hello-world", "hello-world-%").
+				WillReturnRows(rows)
+
+			got, err := New(tt.options...).Suggest(db, "Hello World", tt.n, WithTableName("posts"))
+			if err != nil {
+				t.Fatalf("Sluggable.Suggest() error = %v", err)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Sluggable.Suggest() = %v, want %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+
+	if _, err := New().Suggest(nil, "Hello World", 0); err == nil {
+		t.Error("Sluggable.Suggest() should reject a non-positive n")
+	}
+}
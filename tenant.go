@@ -0,0 +1,73 @@
+package sluggable
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithTenant makes slugs unique per tenant, like WithScope, and sets the tenant column. An explicit tenant
+// takes precedence over WithTenantResolver.
+func WithTenant(column string, value any) sluggableOption {
+	return func(opts *options) {
+		opts.tenantColumn = column
+		WithScope(column, value)(opts)
+	}
+}
+
+// WithTenantColumn sets the column WithTenantResolver scopes by, "tenant_id" by default.
+func WithTenantColumn(column string) sluggableOption {
+	return func(opts *options) {
+		opts.tenantColumn = column
+	}
+}
+
+// WithTenantResolver reads the tenant from the context of every call, e.g. one set by an authentication
+// middleware, so tenant ids don't have to be passed around. Set it once with Configure, see WithTenantColumn.
+// Calls without a tenant in their context fail with ErrTenantRequired, use the Context variants like
+// GenerateWithContext. SimilarWhere and CollisionPredicate have no context, they fail with ErrTenantRequired
+// unless the tenant is passed with WithTenant.
+func WithTenantResolver(resolve func(ctx context.Context) any) sluggableOption {
+	return func(opts *options) {
+		opts.tenantResolver = resolve
+	}
+}
+
+// resolveTenant scopes the options to the tenant of the context, unless a tenant was given explicitly.
+func (o options) resolveTenant(ctx context.Context) (options, error) {
+	if o.tenantResolver == nil {
+		return o, nil
+	}
+
+	if o.hasScope(o.tenantColumn) {
+		return o, nil
+	}
+
+	tenant := o.tenantResolver(ctx)
+	if tenant == nil {
+		return o, fmt.Errorf("%w: no tenant for column %q in the context", ErrTenantRequired, o.tenantColumn)
+	}
+
+	WithScope(o.tenantColumn, tenant)(&o)
+
+	return o, nil
+}
+
+// requireExplicitTenant fails with ErrTenantRequired when the tenant would come from the resolver, for calls
+// without a context.
+func (o options) requireExplicitTenant() error {
+	if o.tenantResolver == nil || o.hasScope(o.tenantColumn) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: pass WithTenant(%q, ...) where there is no context", ErrTenantRequired, o.tenantColumn)
+}
+
+func (o options) hasScope(column string) bool {
+	for _, scope := range o.scopes {
+		if scope.column == column {
+			return true
+		}
+	}
+
+	return false
+}
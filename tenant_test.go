@@ -0,0 +1,188 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type tenantKey struct{}
+
+func TestWithTenantResolver(t *testing.T) {
+	resolver := WithTenantResolver(func(ctx context.Context) any {
+		return ctx.Value(tenantKey{})
+	})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		options []sluggableOption
+		want    string
+		args    []any
+		wantErr error
+	}{
+		{
+			name: "tenant from context",
+			ctx:  context.WithValue(context.Background(), tenantKey{}, 42),
+			want: `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("tenant_id" = $3)`,
+			args: []any{"hello-world", "hello-world-%", 42},
+		},
+		{
+			name:    "explicit tenant wins",
+			ctx:     context.WithValue(context.Background(), tenantKey{}, 42),
+			options: []sluggableOption{WithTenant("tenant_id", 7)},
+			want:    `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("tenant_id" = $3)`,
+			args:    []any{"hello-world", "hello-world-%", 7},
+		},
+		{
+			name:    "missing tenant",
+			ctx:     context.Background(),
+			wantErr: ErrTenantRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			if tt.want != "" {
+				mock.ExpectQuery(tt.want).
+					WithArgs(tt.args[0], tt.args[1], tt.args[2]).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+			}
+
+			s := New(resolver, WithTableName("articles"))
+			if _, err := s.GenerateContext(tt.ctx, db, "Hello World", tt.options...); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sluggable.GenerateContext() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWithTenant(t *testing.T) {
+	s := New(WithTenant("organization_id", 42))
+
+	got, params, err := s.SimilarWhere("hello-world")
+	if err != nil {
+		t.Fatalf("Sluggable.SimilarWhere() error = %v", err)
+	}
+
+	want := `("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("organization_id" = $3)`
+	if got != want {
+		t.Errorf("Sluggable.SimilarWhere() = %v, want %v", got, want)
+	}
+
+	if len(params) != 3 || params[2] != 42 {
+		t.Errorf("Sluggable.SimilarWhere() params = %v, want tenant 42 last", params)
+	}
+
+	if got := New(WithTenantColumn("organization_id")).Options().TenantColumn; got != "organization_id" {
+		t.Errorf("Options().TenantColumn = %v, want %v", got, "organization_id")
+	}
+}
+
+func TestWithTenantColumn(t *testing.T) {
+	s := New(WithTenantColumn("organization_id"), WithTenantResolver(func(ctx context.Context) any {
+		return ctx.Value(tenantKey{})
+	}))
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, 42)
+
+	opts, err := s.resolveOptions(ctx, nil, []sluggableOption{WithAvailabilityChecker(func(context.Context, string) (bool, error) {
+		return true, nil
+	})})
+	if err != nil {
+		t.Fatalf("resolveOptions() error = %v", err)
+	}
+
+	if len(opts.scopes) != 1 || opts.scopes[0].column != "organization_id" || opts.scopes[0].value != 42 {
+		t.Errorf("resolveOptions() scopes = %v, want organization_id = 42", opts.scopes)
+	}
+}
+
+func TestTenantResolverContextVariants(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	s := New(WithTableName("articles"), WithTenantResolver(func(ctx context.Context) any {
+		return ctx.Value(tenantKey{})
+	}))
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, 42)
+	query := `SELECT "id", "slug" FROM "articles" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("tenant_id" = $3)`
+
+	mock.ExpectQuery(query).WithArgs("hello-world", "hello-world-%", 42).WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	if _, err := s.GenerateWithContext(ctx, db, "Hello World", func(string) error { return nil }); err != nil {
+		t.Errorf("Sluggable.GenerateWithContext() error = %v", err)
+	}
+
+	mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 42).WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	if _, err := s.GenerateShortContext(ctx, db, 7); err != nil {
+		t.Errorf("Sluggable.GenerateShortContext() error = %v", err)
+	}
+
+	mock.ExpectQuery(query).WithArgs("t-shirt-red", "t-shirt-red-%", 42).WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	if _, err := s.GenerateVariantContext(ctx, db, "t-shirt", []string{"Red"}); err != nil {
+		t.Errorf("Sluggable.GenerateVariantContext() error = %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(query+" FOR UPDATE").WithArgs("hello-world", "hello-world-%", 42).WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+
+	if _, err := s.GenerateInTxContext(ctx, tx, "Hello World"); err != nil {
+		t.Errorf("Sluggable.GenerateInTxContext() error = %v", err)
+	}
+
+	if _, err := s.GenerateWith(db, "Hello World", func(string) error { return nil }); !errors.Is(err, ErrTenantRequired) {
+		t.Errorf("Sluggable.GenerateWith() error = %v, want %v", err, ErrTenantRequired)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestTenantResolverQueryBuilders(t *testing.T) {
+	s := New(WithTenantResolver(func(ctx context.Context) any {
+		return ctx.Value(tenantKey{})
+	}))
+
+	if _, _, err := s.SimilarWhere("hello-world"); !errors.Is(err, ErrTenantRequired) {
+		t.Errorf("Sluggable.SimilarWhere() error = %v, want %v", err, ErrTenantRequired)
+	}
+
+	if _, _, err := s.CollisionPredicate("hello-world"); !errors.Is(err, ErrTenantRequired) {
+		t.Errorf("Sluggable.CollisionPredicate() error = %v, want %v", err, ErrTenantRequired)
+	}
+
+	got, _, err := s.SimilarWhere("hello-world", WithTenant("tenant_id", 42))
+	if err != nil {
+		t.Fatalf("Sluggable.SimilarWhere() error = %v", err)
+	}
+
+	want := `("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL) AND ("tenant_id" = $3)`
+	if got != want {
+		t.Errorf("Sluggable.SimilarWhere() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package sluggable
+
+import (
+	"fmt"
+)
+
+// Normalize runs the value through the slug pipeline without a database: substitutions, transliteration,
+// the slug method, blocked words, fallbacks and the max length, but no uniqueness suffix. Use it to preview
+// slugs with the same rules as Generate. Returns an empty string when the pipeline fails, e.g. on a blocked
+// word or with FallbackError, Validate reports why.
+func (s *Sluggable) Normalize(value string, options ...sluggableOption) string {
+	opts := s.options // Important: copy instead of pointer reference
+	for _, option := range options {
+		option(&opts)
+	}
+
+	slug, err := opts.normalize(value)
+	if err != nil {
+		return ""
+	}
+
+	return slug
+}
+
+// Validate checks a user-supplied slug, e.g. a custom URL, against the same rules: it must be non-empty,
+// normalize to itself, not be reserved and match WithConstraint. Uniqueness isn't checked, see IsAvailable.
+func (s *Sluggable) Validate(slug string, options ...sluggableOption) error {
+	if slug == "" {
+		return fmt.Errorf("%w: empty slug", ErrSlugTooShort)
+	}
+
+	opts := s.options // Important: copy instead of pointer reference
+	for _, option := range options {
+		option(&opts)
+	}
+
+	normalized, err := opts.normalize(slug)
+	if err != nil {
+		return err
+	}
+
+	if normalized != slug {
+		return fmt.Errorf("%w: %q normalizes to %q", ErrInvalidSlug, slug, normalized)
+	}
+
+	if opts.isReserved(slug) {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidSlug, slug)
+	}
+
+	return opts.checkConstraint(slug)
+}
+
+// normalize returns the slug of the value before any suffix, extension included.
+func (o options) normalize(value string) (string, error) {
+	if err := validateRulesVersion(o.rulesVersion); err != nil {
+		return "", err
+	}
+
+	opts, slug, err := o.baseSlug(value)
+	if err != nil {
+		return "", err
+	}
+
+	return opts.unsuffixed(slug), nil
+}
+
+// Normalize runs the value through the slug pipeline using the global configuration, see Configure.
+func Normalize(value string, options ...sluggableOption) string {
+	return getGlobal().Normalize(value, options...)
+}
+
+// Validate checks a user-supplied slug using the global configuration, see Configure.
+func Validate(slug string, options ...sluggableOption) error {
+	return getGlobal().Validate(slug, options...)
+}
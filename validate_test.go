@@ -0,0 +1,85 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		value   string
+		want    string
+	}{
+		{name: "default", value: "Hello World!", want: "hello-world"},
+		{name: "max length", options: []sluggableOption{WithMaxLength(5)}, value: "Hello World", want: "hello"},
+		{name: "extension", options: []sluggableOption{WithPreset(FileKey())}, value: "Annual Report.PDF", want: "annual-report.pdf"},
+		{name: "blocked word", options: []sluggableOption{WithBlockedWords("secret")}, value: "Secret Plans", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.options...).Normalize(tt.value); got != tt.want {
+				t.Errorf("Sluggable.Normalize(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		slug    string
+		wantErr error
+	}{
+		{name: "valid", slug: "hello-world"},
+		{name: "suffixed", slug: "hello-world-2"},
+		{name: "empty", slug: "", wantErr: ErrSlugTooShort},
+		{name: "uppercase", slug: "Hello-World", wantErr: ErrInvalidSlug},
+		{name: "repeated separators", slug: "hello--world", wantErr: ErrInvalidSlug},
+		{name: "too long", options: []sluggableOption{WithMaxLength(5)}, slug: "hello-world", wantErr: ErrInvalidSlug},
+		{name: "reserved", options: []sluggableOption{WithReserved("admin")}, slug: "admin", wantErr: ErrInvalidSlug},
+		{name: "blocked word", options: []sluggableOption{WithBlockedWords("secret")}, slug: "secret-plans", wantErr: ErrBlockedSlug},
+		{name: "constraint", options: []sluggableOption{WithConstraint(regexp.MustCompile(`^[a-z]+$`))}, slug: "hello-world", wantErr: ErrConstraintViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := New(tt.options...).Validate(tt.slug); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Sluggable.Validate(%q) error = %v, want %v", tt.slug, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithMaxInputLength(t *testing.T) {
+	if got := New().Options().MaxInputLength; got != defaultMaxInputLength {
+		t.Errorf("Options().MaxInputLength = %v, want %v", got, defaultMaxInputLength)
+	}
+
+	s := New(WithMaxInputLength(8))
+
+	if got := s.Normalize("Hello World"); got != "" {
+		t.Errorf("Sluggable.Normalize() = %v, want the value rejected", got)
+	}
+
+	if err := s.Validate("hello-world"); !errors.Is(err, ErrInputTooLong) {
+		t.Errorf("Sluggable.Validate() error = %v, want %v", err, ErrInputTooLong)
+	}
+
+	available := func(context.Context, string) (bool, error) { return true, nil }
+
+	// The fields of GenerateFrom count together, even though each is slugified on its own
+	if _, err := s.GenerateFrom(nil, []string{"Hello", "World"}, WithAvailabilityChecker(available)); !errors.Is(err, ErrInputTooLong) {
+		t.Errorf("Sluggable.GenerateFrom() error = %v, want %v", err, ErrInputTooLong)
+	}
+
+	if got := New(WithMaxInputLength(0)).Normalize(strings.Repeat("a", defaultMaxInputLength+1)); got == "" {
+		t.Errorf("Sluggable.Normalize() = %v, want no limit", got)
+	}
+}
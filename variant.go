@@ -0,0 +1,248 @@
+package sluggable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// verbatimMethod is used for values that are already slugs.
+func verbatimMethod(value, separator string) string {
+	return value
+}
+
+// GenerateVariant generates a unique slug for a variant of the parent slug by appending the slugified
+// attribute values in order, e.g. "t-shirt" with "Red" and "XL" becomes "t-shirt-red-xl".
+//
+// Like Generate the slug is unique in the whole table. When variant slugs are only unique per parent
+// (a unique index on the parent and slug columns), pass WithScope("parent_id", parentID), otherwise the
+// variants of other parents count as taken.
+func (s *Sluggable) GenerateVariant(db contextExecutor, parentSlug string, attributes []string, options ...sluggableOption) (string, error) {
+	return s.GenerateVariantContext(context.Background(), db, parentSlug, attributes, options...)
+}
+
+// GenerateVariantContext is like GenerateVariant with a context.
+func (s *Sluggable) GenerateVariantContext(
+	ctx context.Context, db contextExecutor, parentSlug string, attributes []string, options ...sluggableOption,
+) (string, error) {
+	if parentSlug == "" {
+		return "", fmt.Errorf("[sluggable] parent slug cannot be empty")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return "", err
+	}
+
+	if err := opts.checkInputLength(append([]string{parentSlug}, attributes...)...); err != nil {
+		return "", err
+	}
+
+	// The parent slug is kept as is, the attributes are slugified one by one
+	opts.slugified = true
+
+	result, err := generateDetailed(ctx, db, opts, opts.variantSlug(parentSlug, attributes))
+	if err != nil {
+		return "", err
+	}
+
+	return result.Slug, nil
+}
+
+// GenerateFrom generates a unique slug composed of several fields, each slugified on its own and joined
+// with the separator, e.g. "Electronics" and "iPhone 15 Pro" become "electronics-iphone-15-pro".
+// Fields that slugify to nothing are skipped.
+func (s *Sluggable) GenerateFrom(db contextExecutor, values []string, options ...sluggableOption) (string, error) {
+	return s.GenerateFromContext(context.Background(), db, values, options...)
+}
+
+func (s *Sluggable) GenerateFromContext(ctx context.Context, db contextExecutor, values []string, options ...sluggableOption) (string, error) {
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return "", err
+	}
+
+	// Checked before the fields are slugified one by one
+	if err := opts.checkInputLength(values...); err != nil {
+		return "", err
+	}
+
+	// The fields are slugified one by one, the fallbacks still slugify with the configured method
+	opts.slugified = true
+
+	result, err := generateDetailed(ctx, db, opts, opts.joinedSlug(values))
+	if err != nil {
+		return "", err
+	}
+
+	return result.Slug, nil
+}
+
+// joinedSlug slugifies every value and joins the non-empty parts with the separator.
+func (o options) joinedSlug(values []string) string {
+	parts := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if part := o.slugify(value); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, o.separator)
+}
+
+func (o options) variantSlug(parentSlug string, attributes []string) string {
+	slug := parentSlug
+
+	for _, attribute := range attributes {
+		if part := o.slugify(attribute); part != "" {
+			slug = fmt.Sprint(slug, o.separator, part)
+		}
+	}
+
+	return slug
+}
+
+// GenerateVariants resolves the slugs of several variants of the parent slug with a single query.
+// Attribute values are appended in key order, and variants in the same batch never receive the same slug.
+// Every variant goes through the same checks as Generate (reserved slugs, availability checker, holds,
+// max length, suffix format and constraint) and is logged and warned about like Generate; WithIdentifier
+// is ignored, the variants are new records. The statement timeout covers the whole batch, and with
+// WithAdvisoryLock the lock is taken on the parent slug, which serializes batches of the same parent but
+// not GenerateVariant calls.
+func (s *Sluggable) GenerateVariants(
+	ctx context.Context, db contextExecutor, parentSlug string, attrs []map[string]string, options ...sluggableOption,
+) ([]string, error) {
+	if parentSlug == "" {
+		return nil, fmt.Errorf("[sluggable] parent slug cannot be empty")
+	}
+
+	opts, err := s.resolveOptions(ctx, db, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked up front, so an overlong variant doesn't fail the batch halfway
+	for _, attributes := range attrs {
+		if err := opts.checkInputLength(append([]string{parentSlug}, sortedAttributeValues(attributes)...)...); err != nil {
+			return nil, err
+		}
+	}
+
+	opts.identifier = nil
+	opts.slugified = true
+
+	ctx = opts.previewContext(ctx)
+
+	restoreTimeout := func() error { return nil }
+
+	if opts.statementTimeout > 0 && !opts.sideEffectFree() && db != nil {
+		if restoreTimeout, err = setStatementTimeout(ctx, db, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	slugs, err := generateVariants(ctx, db, opts, parentSlug, attrs)
+
+	// Like generateUnique, keep the lookup error when restoring fails as well
+	if restoreErr := restoreTimeout(); err == nil {
+		err = restoreErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return slugs, nil
+}
+
+// generateVariants resolves the variant slugs against one lookup of the parent slug.
+func generateVariants(ctx context.Context, db contextExecutor, opts options, parentSlug string, attrs []map[string]string) ([]string, error) {
+	if opts.advisoryLock && !opts.sideEffectFree() && db != nil {
+		if err := acquireAdvisoryLock(ctx, db, opts, parentSlug); err != nil {
+			return nil, err
+		}
+	}
+
+	// Every variant slug starts with the parent slug and the separator, so one lookup covers the whole batch.
+	// The suffix separator can differ, e.g. "shirt_red-2", so it can't be used for the pattern.
+	parentOpts := opts
+	parentOpts.suffixSeparator = opts.separator
+
+	stored, _, err := querySimilar(ctx, db, parentOpts, parentSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make(map[string]string, len(attrs))
+
+	lookup := func(base string) (map[string]string, string, error) {
+		found := stored
+
+		// A base truncated below the parent slug isn't covered by the batch lookup
+		if !strings.HasPrefix(opts.foldCase(base), opts.foldCase(parentSlug)) {
+			var err error
+			if found, _, err = querySimilar(ctx, db, opts, base); err != nil {
+				return nil, "", err
+			}
+		}
+
+		simulars := make(map[string]string)
+
+		for _, slugs := range []map[string]string{found, batch} {
+			for key, slug := range slugs {
+				if opts.foldCase(slug) == opts.foldCase(base) || strings.HasPrefix(opts.foldCase(slug), opts.foldCase(base+opts.getSuffixSeparator())) {
+					simulars[key] = slug
+				}
+			}
+		}
+
+		return simulars, "", nil
+	}
+
+	slugs := make([]string, len(attrs))
+
+	for i, attributes := range attrs {
+		value := opts.variantSlug(parentSlug, sortedAttributeValues(attributes))
+
+		result, err := generateAudited(ctx, opts, value, func(opts options) (Result, error) {
+			opts, base, err := opts.baseSlug(value)
+			if err != nil {
+				return Result{}, err
+			}
+
+			return generateFitting(ctx, opts, base, lookup)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.holds != nil && !opts.sideEffectFree() {
+			opts.holds.hold(result.Slug, "")
+		}
+
+		slugs[i] = result.Slug
+
+		// Keyed so they never match a record identifier
+		batch[fmt.Sprintf("\x00batch:%d", i)] = result.Slug
+	}
+
+	return slugs, nil
+}
+
+func sortedAttributeValues(attributes map[string]string) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = attributes[key]
+	}
+
+	return values
+}
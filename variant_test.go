@@ -0,0 +1,343 @@
+package sluggable
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSluggable_GenerateVariant(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes []string
+		existing   []string
+		want       string
+	}{
+		{name: "appends attributes in order", attributes: []string{"Red", "XL"}, want: "t-shirt-red-xl"},
+		{name: "skips empty attributes", attributes: []string{"Navy Blue", "", "!!!"}, want: "t-shirt-navy-blue"},
+		{name: "suffixes taken variants", attributes: []string{"Red"}, existing: []string{"t-shirt-red"}, want: "t-shirt-red-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for _, existing := range tt.existing {
+				rows.AddRow("1", existing)
+			}
+
+			base := tt.want
+			if len(tt.existing) > 0 {
+				base = tt.existing[0]
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "products" WHERE \("slug" = \$1 OR "slug" LIKE \$2\) AND \("deleted_at" IS NULL\) AND \("parent_id" = \$3\)`).
+				WithArgs(base, base+"-%", 7).
+				WillReturnRows(rows)
+
+			s := New(WithUntitled("untitled"))
+
+			got, err := s.GenerateVariant(db, "t-shirt", tt.attributes, WithTableName("products"), WithScope("parent_id", 7))
+			if err != nil {
+				t.Fatalf("Sluggable.GenerateVariant() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.GenerateVariant() = %v, want %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGenerateVariantWithoutScope(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	// The variant of another parent is taken in the whole table
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "products" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("t-shirt-red", "t-shirt-red-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "t-shirt-red"))
+
+	got, err := New().GenerateVariant(db, "t-shirt", []string{"Red"}, WithTableName("products"))
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateVariant() error = %v", err)
+	}
+
+	if got != "t-shirt-red-2" {
+		t.Errorf("Sluggable.GenerateVariant() = %v, want %v", got, "t-shirt-red-2")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSluggable_GenerateFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		options  []sluggableOption
+		existing []string
+		want     string
+	}{
+		{name: "joins the fields", values: []string{"Electronics", "iPhone 15 Pro"}, want: "electronics-iphone-15-pro"},
+		{name: "skips empty fields", values: []string{"", "Books", "!!!", "Go"}, want: "books-go"},
+		{
+			name:    "separator",
+			values:  []string{"Electronics", "iPhone 15"},
+			options: []sluggableOption{WithSeparator("_"), WithMethod(emailLocalPartMethod)},
+			want:    "electronics_iphone_15",
+		},
+		{name: "suffixes taken slugs", values: []string{"Books", "Go"}, existing: []string{"books-go"}, want: "books-go-2"},
+		{name: "untitled", values: []string{"", "!!!"}, options: []sluggableOption{WithUntitled("Untitled Post")}, want: "untitled-post"},
+		{
+			name:    "substitutes once",
+			values:  []string{"C++", "Go"},
+			options: []sluggableOption{WithSubstitutions(map[string]string{"++": " plus plus", "plus": "p"})},
+			want:    "c-plus-plus-go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "slug"})
+			for _, existing := range tt.existing {
+				rows.AddRow("1", existing)
+			}
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "products"`).WillReturnRows(rows)
+
+			got, err := New(WithTableName("products")).GenerateFrom(db, tt.values, tt.options...)
+			if err != nil {
+				t.Fatalf("Sluggable.GenerateFrom() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Sluggable.GenerateFrom() = %v, want %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestSluggable_GenerateVariants(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "slug"}).
+		AddRow("1", "t-shirt-red-xl").
+		AddRow("2", "t-shirt-blue-m").
+		AddRow("3", "t-shirt-blue-m-2")
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "products" WHERE \("slug" = \$1 OR "slug" LIKE \$2\)`).
+		WithArgs("t-shirt", "t-shirt-%").
+		WillReturnRows(rows)
+
+	got, err := New().GenerateVariants(context.Background(), db, "t-shirt", []map[string]string{
+		{"color": "Red", "size": "XL"},
+		{"size": "M", "color": "Blue"},
+		{"color": "Green", "size": "S"},
+		{"color": "Green", "size": "S"},
+	}, WithTableName("products"))
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateVariants() error = %v", err)
+	}
+
+	want := []string{"t-shirt-red-xl-2", "t-shirt-blue-m-3", "t-shirt-green-s", "t-shirt-green-s-2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Sluggable.GenerateVariants() = %v, want %v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGenerateVariantsSuffixSeparator(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "slug"}).
+		AddRow("1", "shirt_red").
+		AddRow("2", "shirt_blue-2")
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "products" WHERE ("slug" = $1 OR "slug" LIKE $2 ESCAPE '!') AND ("deleted_at" IS NULL)`).
+		WithArgs("shirt", "shirt!_%").
+		WillReturnRows(rows)
+
+	got, err := New(WithSeparator("_"), WithSuffixSeparator("-")).GenerateVariants(context.Background(), db, "shirt", []map[string]string{
+		{"color": "Red"},
+		{"color": "Blue"},
+	}, WithTableName("products"))
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateVariants() error = %v", err)
+	}
+
+	want := []string{"shirt_red-2", "shirt_blue-3"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Sluggable.GenerateVariants() = %v, want %v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGenerateVariantsPipeline(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT current_setting('statement_timeout')").
+		WillReturnRows(sqlmock.NewRows([]string{"current_setting"}).AddRow("30s"))
+	mock.ExpectExec("SET LOCAL statement_timeout = 2500").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SELECT pg_advisory_xact_lock(hashtext($1))").
+		WithArgs("t-shirt").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT "id", "slug" FROM "products" WHERE ("slug" = $1 OR "slug" LIKE $2) AND ("deleted_at" IS NULL)`).
+		WithArgs("t-shirt", "t-shirt-%").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}).AddRow("1", "t-shirt-red").AddRow("2", "t-shirt-red-2"))
+	mock.ExpectExec("SELECT set_config('statement_timeout', $1, true)").
+		WithArgs("30s").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var decisions []Decision
+
+	var warnings []Result
+
+	holds := NewHolds(time.Minute)
+
+	s := New(
+		WithStatementTimeout(2500*time.Millisecond),
+		WithAdvisoryLock(),
+		WithHolds(holds),
+		WithDecisionLog(func(_ context.Context, decision Decision) { decisions = append(decisions, decision) }),
+		WithSuffixWarning(2, func(_ context.Context, result Result) { warnings = append(warnings, result) }),
+	)
+
+	got, err := s.GenerateVariants(context.Background(), tx, "t-shirt", []map[string]string{
+		{"color": "Red"},
+		{"color": "Blue"},
+	}, WithTableName("products"))
+	if err != nil {
+		t.Fatalf("Sluggable.GenerateVariants() error = %v", err)
+	}
+
+	want := []string{"t-shirt-red-3", "t-shirt-blue"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Sluggable.GenerateVariants() = %v, want %v", got, want)
+	}
+
+	if len(decisions) != 2 || decisions[0].Slug != "t-shirt-red-3" || decisions[1].Slug != "t-shirt-blue" {
+		t.Errorf("decisions = %+v, want one per variant", decisions)
+	}
+
+	if len(warnings) != 1 || warnings[0].Slug != "t-shirt-red-3" {
+		t.Errorf("warnings = %+v, want t-shirt-red-3", warnings)
+	}
+
+	if !holds.isHeld("t-shirt-blue", "") {
+		t.Errorf("holds.isHeld(%q) = false, want true", "t-shirt-blue")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGenerateVariantsChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []sluggableOption
+		want    []string
+		wantErr error
+	}{
+		{name: "reserved", options: []sluggableOption{WithReserved("t-shirt-red")}, want: []string{"t-shirt-red-2", "t-shirt-blue"}},
+		{
+			name: "availability checker",
+			options: []sluggableOption{WithAvailabilityChecker(func(_ context.Context, slug string) (bool, error) {
+				return slug != "t-shirt-blue", nil
+			})},
+			want: []string{"t-shirt-red", "t-shirt-blue-2"},
+		},
+		{name: "max length", options: []sluggableOption{WithMaxLength(10)}, want: []string{"t-shirt-re", "t-shirt-bl"}},
+		{name: "suffix format", options: []sluggableOption{WithReserved("t-shirt-red"), WithSuffixFormat("%02d")}, want: []string{"t-shirt-red-02", "t-shirt-blue"}},
+		{name: "constraint", options: []sluggableOption{WithConstraint(regexp.MustCompile(`^[a-z-]{1,11}$`))}, wantErr: ErrConstraintViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Failed to create mock database: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT "id", "slug" FROM "products"`).WillReturnRows(sqlmock.NewRows([]string{"id", "slug"}))
+
+			got, err := New(tt.options...).GenerateVariants(context.Background(), db, "t-shirt", []map[string]string{
+				{"color": "Red"},
+				{"color": "Blue"},
+			}, WithTableName("products"))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Sluggable.GenerateVariants() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Sluggable.GenerateVariants() error = %v", err)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Sluggable.GenerateVariants() = %v, want %v", got, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("There were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}